@@ -0,0 +1,298 @@
+package emojid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// alphabetSnapshot is an immutable default alphabet and its version.
+type alphabetSnapshot struct {
+	runes   []rune
+	version uint64
+}
+
+var activeAlphabet atomic.Pointer[alphabetSnapshot]
+
+// SetDefaultAlphabet replaces the alphabet used by New, Parse and the other
+// functions documented to use the default alphabet, for every goroutine, and
+// increments DefaultAlphabetVersion. The alphabet is copied and must pass
+// ValidateAlphabet. Each call that uses the default alphabet reads it once, so a
+// concurrent swap never mixes two alphabets within one New or Parse. IDs issued
+// before the swap may stop parsing if they use removed tokens.
+func SetDefaultAlphabet(alphabet []rune) error {
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return err
+	}
+	runes := append([]rune(nil), alphabet...)
+
+	for {
+		old := activeAlphabet.Load()
+		next := &alphabetSnapshot{runes: runes, version: 1}
+		if old != nil {
+			next.version = old.version + 1
+		}
+		if activeAlphabet.CompareAndSwap(old, next) {
+			return nil
+		}
+	}
+}
+
+// ActiveAlphabet returns a copy of the current default alphabet: the one set with
+// SetDefaultAlphabet, or DefaultAlphabet.
+func ActiveAlphabet() []rune {
+	return append([]rune(nil), defaultAlphabet()...)
+}
+
+// DefaultAlphabetVersion returns the number of successful SetDefaultAlphabet
+// calls, so 0 means DefaultAlphabet is in use. Callers can record it next to
+// issued IDs to tell which alphabet they came from.
+func DefaultAlphabetVersion() uint64 {
+	if s := activeAlphabet.Load(); s != nil {
+		return s.version
+	}
+	return 0
+}
+
+// defaultAlphabet returns the current default alphabet without copying it. The
+// result must not be modified.
+func defaultAlphabet() []rune {
+	if s := activeAlphabet.Load(); s != nil {
+		return s.runes
+	}
+	return DefaultAlphabet
+}
+
+// MaxAlphabetSize is the largest alphabet that can generate EmojiIDs: tokens are
+// drawn from 16-bit random values.
+const MaxAlphabetSize = 1 << 16
+
+// ValidateAlphabet reports whether alphabet can be used to generate and parse
+// EmojiIDs: it must contain between 2 and MaxAlphabetSize entries, no
+// duplicates, and no entry may be the '-' group separator or whitespace. Entries
+// that cannot be encoded as UTF-8, i.e. surrogate halves (U+D800-U+DFFF) and
+// values outside the Unicode range, are reported as ErrInvalidCodepoint.
+func ValidateAlphabet(alphabet []rune) error {
+	if len(alphabet) < 2 {
+		return ErrAlphabetTooSmall
+	}
+	if len(alphabet) > MaxAlphabetSize {
+		return fmt.Errorf("%w: %d entries exceed the limit of %d", ErrInvalidAlphabet, len(alphabet), MaxAlphabetSize)
+	}
+
+	seen := make(map[rune]struct{}, len(alphabet))
+	for _, r := range alphabet {
+		if !utf8.ValidRune(r) {
+			return fmt.Errorf("%w: %U", ErrInvalidCodepoint, r)
+		}
+		if r == '-' || unicode.IsSpace(r) {
+			return fmt.Errorf("%w: %q is reserved as a separator", ErrInvalidAlphabet, r)
+		}
+		if _, dup := seen[r]; dup {
+			return fmt.Errorf("%w: duplicate entry %q", ErrInvalidAlphabet, string(r))
+		}
+		seen[r] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateAlphabetStrict is like ValidateAlphabet but also rejects private-use
+// code points with ErrInvalidCodepoint. Their glyphs depend on the installed
+// fonts, so IDs using them usually render as blank boxes.
+func ValidateAlphabetStrict(alphabet []rune) error {
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return err
+	}
+	for _, r := range alphabet {
+		if unicode.Is(unicode.Co, r) {
+			return fmt.Errorf("%w: %U is a private-use code point", ErrInvalidCodepoint, r)
+		}
+	}
+	return nil
+}
+
+// LoadAlphabet reads an alphabet from r, e.g. an ops-managed config file.
+// Entries are separated by newlines or other whitespace; blank lines and lines
+// starting with '#' are skipped. Every entry must be a single code point, and the
+// result must pass ValidateAlphabet.
+func LoadAlphabet(r io.Reader) ([]rune, error) {
+	var alphabet []rune
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if !utf8.ValidString(text) {
+			return nil, fmt.Errorf("%w: line %d: invalid UTF-8", ErrInvalidAlphabet, line)
+		}
+
+		for _, field := range strings.Fields(text) {
+			if utf8.RuneCountInString(field) != 1 {
+				return nil, fmt.Errorf("%w: line %d: %q is not a single code point", ErrInvalidAlphabet, line, field)
+			}
+			r, _ := utf8.DecodeRuneInString(field)
+			alphabet = append(alphabet, r)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return nil, err
+	}
+	return alphabet, nil
+}
+
+// AlphabetsCompatible reports whether IDs can be recoded between alphabets a
+// and b by token index: both must pass ValidateAlphabet and have the same length.
+func AlphabetsCompatible(a, b []rune) error {
+	if err := ValidateAlphabet(a); err != nil {
+		return fmt.Errorf("first alphabet: %w", err)
+	}
+	if err := ValidateAlphabet(b); err != nil {
+		return fmt.Errorf("second alphabet: %w", err)
+	}
+	if len(a) != len(b) {
+		return fmt.Errorf("%w: lengths differ (%d vs %d)", ErrInvalidAlphabet, len(a), len(b))
+	}
+	return nil
+}
+
+// CheckAlphabet is a conformance check for custom alphabets, meant to be called
+// from consumers' tests:
+//
+//	if err := emojid.CheckAlphabet(myAlphabet); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// It runs ValidateAlphabet, then formats and re-parses random IDs and IDs that
+// together use every entry, and reports the first ID that does not round-trip.
+func CheckAlphabet(alphabet []rune) error {
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return err
+	}
+
+	var ids []EmojiID
+	for range 16 {
+		id, err := NewWithAlphabet(alphabet)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	// Cover every entry at least once, TokenCount entries per ID.
+	for start := 0; start < len(alphabet); start += TokenCount {
+		indices := make([]int, TokenCount)
+		for i := range indices {
+			indices[i] = (start + i) % len(alphabet)
+		}
+		id, err := FromIndices(indices, alphabet)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		s := id.String()
+		got, err := ParseWithAlphabet(s, alphabet)
+		if err != nil {
+			return fmt.Errorf("round trip of %q: %w", s, err)
+		}
+		if !got.Equal(id) {
+			return fmt.Errorf("round trip of %q: got %q", s, got.String())
+		}
+	}
+	return nil
+}
+
+// AlphabetFromRanges builds an alphabet from inclusive code point ranges such as
+// {0x1F400, 0x1F43E} (animals), in range order. A range whose start exceeds its
+// end or that lies outside [0, unicode.MaxRune] is an error. Within valid ranges,
+// code points that are not single-code-point emoji with default emoji
+// presentation (the same Unicode 15.1 table runeWidth uses) are skipped, as are
+// repeats from overlapping ranges, so a range may span unassigned gaps. The
+// result must pass ValidateAlphabet.
+func AlphabetFromRanges(ranges [][2]rune) ([]rune, error) {
+	var alphabet []rune
+	seen := make(map[rune]struct{})
+	for i, rg := range ranges {
+		lo, hi := rg[0], rg[1]
+		if lo < 0 || hi > unicode.MaxRune || lo > hi {
+			return nil, fmt.Errorf("%w: range %d [%U, %U] is invalid", ErrInvalidAlphabet, i, lo, hi)
+		}
+		for r := lo; r <= hi; r++ {
+			if !unicode.Is(emojiPresentation, r) {
+				continue
+			}
+			if _, dup := seen[r]; dup {
+				continue
+			}
+			seen[r] = struct{}{}
+			alphabet = append(alphabet, r)
+		}
+	}
+
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return nil, err
+	}
+	return alphabet, nil
+}
+
+// AlphabetDiff compares two alphabets for a migration: added lists the entries of
+// new that are not in old, in new's order, and removed lists the entries of old
+// that are not in new, in old's order. IDs using a removed entry stop parsing with
+// the new alphabet; UsesAny finds them.
+func AlphabetDiff(old, new []rune) (added, removed []rune) {
+	oldSet, newSet := cachedAlphabetSet(old), cachedAlphabetSet(new)
+	for _, r := range new {
+		if _, ok := oldSet[r]; !ok && !slices.Contains(added, r) {
+			added = append(added, r)
+		}
+	}
+	for _, r := range old {
+		if _, ok := newSet[r]; !ok && !slices.Contains(removed, r) {
+			removed = append(removed, r)
+		}
+	}
+	return added, removed
+}
+
+// UsesAny reports whether any token of the ID is one of tokens, e.g. the removed
+// entries from AlphabetDiff.
+func (e EmojiID) UsesAny(tokens []rune) bool {
+	set := alphabetSet(tokens)
+	for _, r := range e.tokens {
+		if _, ok := set[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidUnder returns the IDs in ids, in order, that contain at least one token
+// absent from newAlphabet and so would no longer parse after switching to it.
+func InvalidUnder(ids []EmojiID, newAlphabet []rune) []EmojiID {
+	allowed := cachedAlphabetSet(newAlphabet)
+
+	var invalid []EmojiID
+	for _, id := range ids {
+		for _, r := range id.tokens {
+			if _, ok := allowed[r]; !ok {
+				invalid = append(invalid, id)
+				break
+			}
+		}
+	}
+	return invalid
+}
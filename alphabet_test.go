@@ -0,0 +1,294 @@
+package emojid
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLoadAlphabet(t *testing.T) {
+	const file = `# production alphabet
+😀 😃
+
+# animals
+🐶
+	🐱   🐭
+`
+	got, err := LoadAlphabet(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []rune{'😀', '😃', '🐶', '🐱', '🐭'}; !slices.Equal(got, want) {
+		t.Errorf("LoadAlphabet = %q, want %q", string(got), string(want))
+	}
+}
+
+func TestLoadAlphabetErrors(t *testing.T) {
+	for name, file := range map[string]string{
+		"multi-codepoint": "😀\n👍🏽\n🐶\n",
+		"duplicate":       "😀\n🐶\n😀\n",
+		"dash":            "😀\n-\n",
+		"too small":       "# only one\n😀\n",
+		"invalid UTF-8":   "😀\n\xff\n",
+	} {
+		_, err := LoadAlphabet(strings.NewReader(file))
+		if err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+
+	_, err := LoadAlphabet(strings.NewReader("😀\n👍🏽\n"))
+	if !errors.Is(err, ErrInvalidAlphabet) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("multi-codepoint entry: got %v, want ErrInvalidAlphabet naming line 2", err)
+	}
+}
+
+func TestValidateAlphabet(t *testing.T) {
+	if err := ValidateAlphabet(DefaultAlphabet); err != nil {
+		t.Errorf("DefaultAlphabet: %v", err)
+	}
+	if err := ValidateAlphabet([]rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("one entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+	for _, a := range [][]rune{{'😀', ' '}, {'😀', '\n'}, {'😀', '-'}, {'😀', '🐶', '😀'}} {
+		if err := ValidateAlphabet(a); !errors.Is(err, ErrInvalidAlphabet) {
+			t.Errorf("%q: got %v, want ErrInvalidAlphabet", string(a), err)
+		}
+	}
+}
+
+func TestValidateAlphabetTooLarge(t *testing.T) {
+	a := make([]rune, MaxAlphabetSize+1)
+	for i := range a {
+		a[i] = 0x20000 + rune(i)
+	}
+	if err := ValidateAlphabet(a); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("%d entries: got %v, want ErrInvalidAlphabet", len(a), err)
+	}
+	if _, err := NewWithAlphabet(a); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("NewWithAlphabet with %d entries: got %v, want ErrInvalidAlphabet", len(a), err)
+	}
+	if err := ValidateAlphabet(a[:MaxAlphabetSize]); err != nil {
+		t.Errorf("%d entries: %v", MaxAlphabetSize, err)
+	}
+}
+
+func TestAlphabetsCompatible(t *testing.T) {
+	if err := AlphabetsCompatible(DefaultAlphabet, ShuffleAlphabet(DefaultAlphabet, 1)); err != nil {
+		t.Errorf("same-size alphabets: %v", err)
+	}
+	if err := AlphabetsCompatible(testFaces, testAnimals); err != nil {
+		t.Errorf("faces and animals: %v", err)
+	}
+
+	if err := AlphabetsCompatible(testFaces, testAnimals[:7]); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("different lengths: got %v, want ErrInvalidAlphabet", err)
+	}
+	err := AlphabetsCompatible(testFaces, []rune{'x'})
+	if !errors.Is(err, ErrAlphabetTooSmall) || !strings.HasPrefix(err.Error(), "second alphabet") {
+		t.Errorf("invalid second alphabet: got %v", err)
+	}
+}
+
+func TestCheckAlphabet(t *testing.T) {
+	for name, a := range map[string][]rune{
+		"default": DefaultAlphabet,
+		"small":   {'🍎', '🍊', '🍋'},
+		"animals": testAnimals,
+	} {
+		if err := CheckAlphabet(a); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	if err := CheckAlphabet([]rune{'🍎', '🍎'}); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("duplicate entry: got %v, want ErrInvalidAlphabet", err)
+	}
+}
+
+func TestAlphabetFromRanges(t *testing.T) {
+	// Animal faces, with the overlapping second range adding nothing new.
+	got, err := AlphabetFromRanges([][2]rune{{0x1F42D, 0x1F431}, {0x1F430, 0x1F431}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []rune{'🐭', '🐮', '🐯', '🐰', '🐱'}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", string(got), string(want))
+	}
+
+	// Text-presentation and ASCII code points are skipped.
+	got, err = AlphabetFromRanges([][2]rune{{'a', 'z'}, {0x2600, 0x2603}, {0x1F600, 0x1F601}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []rune{'😀', '😁'}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", string(got), string(want))
+	}
+}
+
+func TestAlphabetFromRangesErrors(t *testing.T) {
+	for _, ranges := range [][][2]rune{
+		{{0x1F601, 0x1F600}},
+		{{-1, 0x1F600}},
+		{{0x1F600, 0x110000}},
+	} {
+		if _, err := AlphabetFromRanges(ranges); !errors.Is(err, ErrInvalidAlphabet) {
+			t.Errorf("AlphabetFromRanges(%U): got %v, want ErrInvalidAlphabet", ranges, err)
+		}
+	}
+	// Too few emoji left after skipping.
+	for _, ranges := range [][][2]rune{{{'a', 'z'}}, {{0x1F600, 0x1F600}}} {
+		if _, err := AlphabetFromRanges(ranges); err == nil {
+			t.Errorf("AlphabetFromRanges(%U) succeeded", ranges)
+		}
+	}
+}
+
+func TestSetDefaultAlphabet(t *testing.T) {
+	t.Cleanup(func() { SetDefaultAlphabet(DefaultAlphabet) })
+
+	before := DefaultAlphabetVersion()
+	alphabet := slices.Clone(testFaces)
+	if err := SetDefaultAlphabet(alphabet); err != nil {
+		t.Fatal(err)
+	}
+	if got := DefaultAlphabetVersion(); got != before+1 {
+		t.Errorf("version = %d, want %d", got, before+1)
+	}
+	alphabet[0] = '🐶' // the default is a copy
+	if got := ActiveAlphabet(); !slices.Equal(got, testFaces) {
+		t.Errorf("ActiveAlphabet() = %q, want %q", string(got), string(testFaces))
+	}
+
+	id := MustNew()
+	if _, err := ParseWithAlphabet(id.String(), testFaces); err != nil {
+		t.Errorf("New did not use the default alphabet: %v", err)
+	}
+	if _, err := Parse(id.String()); err != nil {
+		t.Errorf("Parse did not use the default alphabet: %v", err)
+	}
+
+	if err := SetDefaultAlphabet([]rune{'😀'}); err == nil {
+		t.Error("invalid alphabet accepted")
+	}
+	if got := DefaultAlphabetVersion(); got != before+1 {
+		t.Errorf("failed swap changed the version to %d", got)
+	}
+}
+
+func TestSetDefaultAlphabetConcurrent(t *testing.T) {
+	t.Cleanup(func() { SetDefaultAlphabet(DefaultAlphabet) })
+	if err := SetDefaultAlphabet(testFaces); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every ID comes from a single snapshot, never a mix of the two alphabets.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 1000 {
+			SetDefaultAlphabet([][]rune{testFaces, testAnimals}[i%2])
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				id, err := New()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				s := id.String()
+				_, errFaces := ParseWithAlphabet(s, testFaces)
+				_, errAnimals := ParseWithAlphabet(s, testAnimals)
+				if errFaces != nil && errAnimals != nil {
+					t.Errorf("%v mixes alphabets", id)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+}
+
+func TestAlphabetDiff(t *testing.T) {
+	old := []rune{'🍎', '🍊', '🍋', '🍉'}
+	new := []rune{'🍇', '🍊', '🍎', '🍓', '🍇'}
+	added, removed := AlphabetDiff(old, new)
+	if want := []rune{'🍇', '🍓'}; !slices.Equal(added, want) {
+		t.Errorf("added = %q, want %q", string(added), string(want))
+	}
+	if want := []rune{'🍋', '🍉'}; !slices.Equal(removed, want) {
+		t.Errorf("removed = %q, want %q", string(removed), string(want))
+	}
+
+	if added, removed := AlphabetDiff(DefaultAlphabet, DefaultAlphabet); added != nil || removed != nil {
+		t.Errorf("identical alphabets: %q, %q", string(added), string(removed))
+	}
+
+	id, err := FromIndices(make([]int, TokenCount), old) // all 🍎
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.UsesAny(removed) {
+		t.Error("UsesAny reported a removed token")
+	}
+	id.tokens[5] = '🍋'
+	if !id.UsesAny(removed) {
+		t.Error("UsesAny missed a removed token")
+	}
+}
+
+func TestInvalidUnder(t *testing.T) {
+	faces := make([]EmojiID, 3)
+	for i := range faces {
+		id, err := NewWithAlphabet(testFaces)
+		if err != nil {
+			t.Fatal(err)
+		}
+		faces[i] = id
+	}
+	mixed := faces[1]
+	mixed.tokens[TokenCount-1] = testAnimals[0]
+
+	ids := []EmojiID{faces[0], mixed, faces[2], MustNew()}
+	got := InvalidUnder(ids, testFaces)
+	if want := []EmojiID{mixed, ids[3]}; !slices.Equal(got, want) {
+		t.Errorf("InvalidUnder = %v, want %v", got, want)
+	}
+	if got := InvalidUnder(faces, testFaces); got != nil {
+		t.Errorf("all valid: %v, want none", got)
+	}
+	if got := InvalidUnder(faces, append(slices.Clone(testFaces), testAnimals...)); got != nil {
+		t.Errorf("superset alphabet: %v, want none", got)
+	}
+}
+
+func TestValidateAlphabetCodepoints(t *testing.T) {
+	for _, r := range []rune{0xD800, 0xDFFF, 0x110000, -1} {
+		if err := ValidateAlphabet([]rune{'😀', r}); !errors.Is(err, ErrInvalidCodepoint) {
+			t.Errorf("%U: got %v, want ErrInvalidCodepoint", r, err)
+		}
+	}
+
+	// Private-use code points are valid UTF-8 and only rejected in strict mode.
+	for _, r := range []rune{0xE000, 0xF8FF, 0xF0000, 0x10FFFD} {
+		a := []rune{'😀', r}
+		if err := ValidateAlphabet(a); err != nil {
+			t.Errorf("%U: %v", r, err)
+		}
+		if err := ValidateAlphabetStrict(a); !errors.Is(err, ErrInvalidCodepoint) {
+			t.Errorf("strict %U: got %v, want ErrInvalidCodepoint", r, err)
+		}
+	}
+	if err := ValidateAlphabetStrict(DefaultAlphabet); err != nil {
+		t.Errorf("strict DefaultAlphabet: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package emojid
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// maxCachedAlphabets caps the lookup table cache. Programs normally use a handful
+// of alphabets; one that builds them dynamically simply loses caching once the
+// cap is reached, because the whole cache is then dropped and refilled.
+const maxCachedAlphabets = 64
+
+// alphabetTables holds the lookup maps derived from one alphabet. They are shared
+// between callers and must not be modified.
+type alphabetTables struct {
+	alphabet []rune // private copy, compared on every hit
+	index    map[rune]int
+	set      map[rune]struct{}
+	sorted   []rune // distinct entries in code point order
+}
+
+var (
+	tablesCache     sync.Map // alphabetHash -> *alphabetTables
+	tablesCacheSize atomic.Int64
+)
+
+// cachedTables returns the lookup tables for alphabet, building them on first use.
+// Entries are keyed by a hash of the alphabet contents and verified against a
+// copy, so a caller that mutates its slice gets fresh tables rather than stale
+// ones.
+func cachedTables(alphabet []rune) *alphabetTables {
+	h := alphabetHash(alphabet)
+	if v, ok := tablesCache.Load(h); ok {
+		if t := v.(*alphabetTables); slices.Equal(t.alphabet, alphabet) {
+			return t
+		}
+	}
+
+	t := &alphabetTables{
+		alphabet: slices.Clone(alphabet),
+		index:    buildAlphabetIndex(alphabet),
+		set:      alphabetSet(alphabet),
+		sorted:   slices.Compact(slices.Sorted(slices.Values(alphabet))),
+	}
+	if tablesCacheSize.Add(1) > maxCachedAlphabets {
+		tablesCache.Clear()
+		tablesCacheSize.Store(1)
+	}
+	tablesCache.Store(h, t)
+	return t
+}
+
+// alphabetHash is FNV-1a over the alphabet's code points.
+func alphabetHash(alphabet []rune) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+
+	h := uint64(offset64)
+	for _, r := range alphabet {
+		h ^= uint64(r)
+		h *= prime64
+	}
+	return h
+}
+
+// alphabetIndex maps each alphabet entry to its position. For duplicate entries
+// the first position wins. The map is cached and must not be modified.
+func alphabetIndex(alphabet []rune) map[rune]int {
+	return cachedTables(alphabet).index
+}
+
+// cachedAlphabetSet is like alphabetSet but cached. The map must not be modified.
+func cachedAlphabetSet(alphabet []rune) map[rune]struct{} {
+	return cachedTables(alphabet).set
+}
+
+// sortedAlphabet returns the distinct entries of alphabet in code point order,
+// the order Compare uses. The slice is cached and must not be modified.
+func sortedAlphabet(alphabet []rune) []rune {
+	return cachedTables(alphabet).sorted
+}
+
+// buildAlphabetIndex builds the map returned by alphabetIndex.
+func buildAlphabetIndex(alphabet []rune) map[rune]int {
+	index := make(map[rune]int, len(alphabet))
+	for i := len(alphabet) - 1; i >= 0; i-- {
+		index[alphabet[i]] = i
+	}
+	return index
+}
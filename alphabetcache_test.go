@@ -0,0 +1,66 @@
+package emojid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCachedTables(t *testing.T) {
+	alphabet := []rune{'🍊', '🍎', '🍋', '🍎'}
+	tables := cachedTables(alphabet)
+	if cachedTables(alphabet) != tables {
+		t.Error("second lookup missed the cache")
+	}
+	if cachedTables(slices.Clone(alphabet)) != tables {
+		t.Error("equal contents in another slice missed the cache")
+	}
+
+	if got := tables.index; len(got) != 3 || got['🍎'] != 1 || got['🍋'] != 2 {
+		t.Errorf("index = %v, want the first position of each entry", got)
+	}
+	if len(tables.set) != 3 {
+		t.Errorf("set has %d entries, want 3", len(tables.set))
+	}
+	if want := []rune{'🍊', '🍋', '🍎'}; !slices.Equal(tables.sorted, want) {
+		t.Errorf("sorted = %q, want %q", string(tables.sorted), string(want))
+	}
+
+	// Mutating the caller's slice must not return stale tables.
+	alphabet[0] = '🍉'
+	if _, ok := cachedAlphabetSet(alphabet)['🍉']; !ok {
+		t.Error("stale tables after the alphabet changed")
+	}
+	if _, ok := tables.set['🍉']; ok {
+		t.Error("cached tables changed with the caller's slice")
+	}
+}
+
+func TestCachedTablesCap(t *testing.T) {
+	for i := range 2 * maxCachedAlphabets {
+		alphabet := []rune{'😀', rune(0x1F400 + i)}
+		if got := alphabetIndex(alphabet)[alphabet[1]]; got != 1 {
+			t.Fatalf("alphabet %d: index = %d, want 1", i, got)
+		}
+	}
+	if n := tablesCacheSize.Load(); n > maxCachedAlphabets {
+		t.Errorf("cache holds %d alphabets, cap is %d", n, maxCachedAlphabets)
+	}
+}
+
+func TestIndicesAllocs(t *testing.T) {
+	id := MustNew()
+	id.Indices(DefaultAlphabet) // warm the cache
+	// Only the returned slice is allocated.
+	if n := testing.AllocsPerRun(100, func() { id.Indices(DefaultAlphabet) }); n > 1 {
+		t.Errorf("Indices allocates %v times, want at most 1", n)
+	}
+}
+
+func BenchmarkIndices(b *testing.B) {
+	id := MustNew()
+	for b.Loop() {
+		if _, err := id.Indices(DefaultAlphabet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package emojid
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// CrossPlatformAlphabet is the subset of DefaultAlphabet that looks consistent
+// across the Apple, Google, Microsoft, Samsung and Twemoji designs, for IDs shared
+// between users on different platforms. It leaves out:
+//
+//   - symbols whose default presentation is text (🌶 ✈ 🛰 ❄ 🛡 ⚙ 🗄), which render
+//     as monochrome glyphs on some platforms unless followed by U+FE0F, and
+//   - face and flower variants that only differ in small details that each vendor
+//     draws differently (😃 😄 😁 😆 😌 😗 😙 😚 😛 😝 🌼), so users cannot tell
+//     them apart from their neighbours reliably.
+//
+// It has 134 entries, about 226 bits per ID.
+var CrossPlatformAlphabet = without(DefaultAlphabet,
+	'🌶', '✈', '🛰', '❄', '🛡', '⚙', '🗄',
+	'😃', '😄', '😁', '😆', '😌', '😗', '😙', '😚', '😛', '😝', '🌼',
+)
+
+// NewCrossPlatform returns a new random EmojiID using CrossPlatformAlphabet.
+func NewCrossPlatform() (EmojiID, error) {
+	return NewWithAlphabet(CrossPlatformAlphabet)
+}
+
+// NarrowAlphabet is the subset of DefaultAlphabet that occupies a single terminal
+// column, for terminal UIs where double-width emoji misalign columns. These are
+// the symbols whose default presentation is text (🌶 ✈ 🛰 ❄ 🛡 ⚙ 🗄); terminals
+// draw them one column wide unless they are followed by U+FE0F.
+//
+// The set is small: 7 entries give about 90 bits per ID instead of 232, which is
+// fine for display handles but too weak for secrets.
+var NarrowAlphabet = slices.DeleteFunc(slices.Clone(DefaultAlphabet), func(r rune) bool {
+	return runeWidth(r) != 1
+})
+
+// NewNarrow returns a new random EmojiID using NarrowAlphabet.
+func NewNarrow() (EmojiID, error) {
+	return NewWithAlphabet(NarrowAlphabet)
+}
+
+// ShuffleAlphabet returns a copy of a permuted deterministically by seed, e.g. to
+// give each tenant the same emoji in its own order. The same seed always yields
+// the same permutation, on every platform and Go release: it is a Fisher-Yates
+// shuffle driven by a PCG generator seeded with seed, which is not
+// cryptographically secure and only affects presentation. Generation with the
+// shuffled alphabet is as uniform as with a, and IDs parse with either.
+func ShuffleAlphabet(a []rune, seed int64) []rune {
+	out := slices.Clone(a)
+	pcg := rand.NewPCG(uint64(seed), 0)
+	for i := len(out) - 1; i > 0; i-- {
+		// The modulo bias over 64 bits is negligible for alphabet sizes.
+		j := int(pcg.Uint64() % uint64(i+1))
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// without returns a copy of alphabet with the given entries removed.
+func without(alphabet []rune, remove ...rune) []rune {
+	return slices.DeleteFunc(slices.Clone(alphabet), func(r rune) bool {
+		return slices.Contains(remove, r)
+	})
+}
@@ -0,0 +1,83 @@
+package emojid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCrossPlatformAlphabet(t *testing.T) {
+	if got := len(CrossPlatformAlphabet); got != 134 {
+		t.Errorf("len = %d, want 134", got)
+	}
+	if err := ValidateAlphabet(CrossPlatformAlphabet); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range CrossPlatformAlphabet {
+		if !slices.Contains(DefaultAlphabet, r) {
+			t.Errorf("%q is not in DefaultAlphabet", string(r))
+		}
+	}
+	for _, r := range []rune{'✈', '❄', '😃', '🌼'} {
+		if slices.Contains(CrossPlatformAlphabet, r) {
+			t.Errorf("%q should be excluded", string(r))
+		}
+	}
+
+	id, err := NewCrossPlatform()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseWithAlphabet(id.String(), CrossPlatformAlphabet); err != nil {
+		t.Errorf("round trip of %v: %v", id, err)
+	}
+}
+
+func TestNarrowAlphabet(t *testing.T) {
+	if got := len(NarrowAlphabet); got != 7 {
+		t.Errorf("len = %d, want 7", got)
+	}
+	for _, r := range NarrowAlphabet {
+		if w := runeWidth(r); w != 1 {
+			t.Errorf("%q has width %d", string(r), w)
+		}
+	}
+
+	id, err := NewNarrow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 32 tokens and 4 dashes, one column each.
+	if got := stringWidth(id.String()); got != TokenCount+len(StandardLayout)-1 {
+		t.Errorf("width of %v = %d, want %d", id, got, TokenCount+len(StandardLayout)-1)
+	}
+}
+
+func TestShuffleAlphabet(t *testing.T) {
+	a := ShuffleAlphabet(DefaultAlphabet, 7)
+	if !slices.Equal(a, ShuffleAlphabet(DefaultAlphabet, 7)) {
+		t.Error("same seed gave different orders")
+	}
+	if slices.Equal(a, ShuffleAlphabet(DefaultAlphabet, 8)) {
+		t.Error("different seeds gave the same order")
+	}
+	if slices.Equal(a, DefaultAlphabet) {
+		t.Error("shuffle kept the original order")
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(DefaultAlphabet))) {
+		t.Error("shuffle is not a permutation")
+	}
+
+	// The order is part of the API: tenants rely on it staying the same.
+	if got, want := string(ShuffleAlphabet(testFaces, 42)), "😅😆😄😃😀😁😂🤣"; got != want {
+		t.Errorf("ShuffleAlphabet(testFaces, 42) = %s, want %s", got, want)
+	}
+
+	// IDs from the shuffled alphabet parse with the original.
+	id, err := NewWithAlphabet(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(id.String()); err != nil {
+		t.Error(err)
+	}
+}
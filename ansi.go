@@ -0,0 +1,39 @@
+package emojid
+
+import (
+	"os"
+	"strings"
+)
+
+// NoColor disables the escape codes of ANSIColored. It defaults to true when the
+// NO_COLOR environment variable is set to a non-empty value (see no-color.org).
+// Set it during program initialization, e.g. from a --no-color flag; it is not
+// safe to change concurrently with ANSIColored.
+var NoColor = os.Getenv("NO_COLOR") != ""
+
+// ansiGroupColors are the SGR foreground codes cycled through by ANSIColored:
+// red, green, yellow, blue, magenta, cyan.
+var ansiGroupColors = []string{"31", "32", "33", "34", "35", "36"}
+
+const ansiReset = "\x1b[0m"
+
+// ANSIColored formats the ID like String but wraps each group of the default
+// layout in its own ANSI foreground color, for terminal output. Emoji glyphs are
+// usually drawn in full color regardless, so terminals that tint them are where
+// this helps most. With NoColor set it returns String unchanged.
+func (e EmojiID) ANSIColored() string {
+	if NoColor || e.IsZero() {
+		return e.String()
+	}
+
+	var b strings.Builder
+	for g, group := range e.Groups() {
+		if g > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString("\x1b[" + ansiGroupColors[g%len(ansiGroupColors)] + "m")
+		b.WriteString(string(group))
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package emojid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestANSIColored(t *testing.T) {
+	old := NoColor
+	t.Cleanup(func() { NoColor = old })
+	NoColor = false
+
+	id := MustNew()
+	s := id.ANSIColored()
+	for g, code := range []string{"31", "32", "33", "34", "35"} {
+		if !strings.Contains(s, "\x1b["+code+"m") {
+			t.Errorf("group %d: missing color %s", g+1, code)
+		}
+	}
+	if n := strings.Count(s, ansiReset); n != len(StandardLayout) {
+		t.Errorf("%d resets, want %d", n, len(StandardLayout))
+	}
+
+	plain := regexp.MustCompile("\x1b\\[[0-9]+m").ReplaceAllString(s, "")
+	if plain != id.String() {
+		t.Errorf("without escapes = %q, want %q", plain, id.String())
+	}
+
+	if got := Nil.ANSIColored(); got != "" {
+		t.Errorf("Nil = %q, want empty", got)
+	}
+}
+
+func TestANSIColoredNoColor(t *testing.T) {
+	old := NoColor
+	t.Cleanup(func() { NoColor = old })
+	NoColor = true
+
+	id := MustNew()
+	if got := id.ANSIColored(); got != id.String() {
+		t.Errorf("with NoColor = %q, want %q", got, id.String())
+	}
+}
@@ -0,0 +1,94 @@
+package emojid
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Next returns the ID that immediately follows e in Compare order among all IDs
+// over alphabet, treating the tokens as a 32-digit number whose digits are the
+// ranks of the alphabet's distinct entries sorted by code point. Like an odometer,
+// the last token advances and carries into the ones before it. Next does not wrap:
+// the largest ID (every token the highest entry) returns ErrOverflow. Every token
+// must be in alphabet.
+func (e EmojiID) Next(alphabet []rune) (EmojiID, error) {
+	return e.step(alphabet, 1)
+}
+
+// Prev returns the ID that immediately precedes e in Compare order, the inverse of
+// Next. The smallest ID (every token the lowest entry) returns ErrOverflow.
+func (e EmojiID) Prev(alphabet []rune) (EmojiID, error) {
+	return e.step(alphabet, -1)
+}
+
+// Range returns an iterator over the IDs from from to to inclusive, in Compare
+// order, stepping with Next. It is empty if from sorts after to. Both IDs must use
+// only tokens from alphabet, which Range checks up front. The iterator is lazy, so
+// a range spanning far more IDs than a caller can hold is safe to walk partially;
+// stop early by breaking out of the loop.
+func Range(from, to EmojiID, alphabet []rune) (iter.Seq[EmojiID], error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+	if _, err := from.Indices(alphabet); err != nil {
+		return nil, err
+	}
+	if _, err := to.Indices(alphabet); err != nil {
+		return nil, err
+	}
+
+	return func(yield func(EmojiID) bool) {
+		if from.Compare(to) > 0 {
+			return
+		}
+		for id := from; ; {
+			if !yield(id) || id == to {
+				return
+			}
+			// Cannot fail: id < to, so a successor exists and uses alphabet.
+			id, _ = id.Next(alphabet)
+		}
+	}, nil
+}
+
+// step adds dir (+1 or -1) to the ID's rank number.
+func (e EmojiID) step(alphabet []rune, dir int) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+	sorted := sortedAlphabet(alphabet)
+	if len(sorted) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	out := e
+	for i := TokenCount - 1; i >= 0; i-- {
+		rank, ok := slices.BinarySearch(sorted, e.tokens[i])
+		if !ok {
+			return EmojiID{}, &TokenError{Index: i, Token: e.tokens[i]}
+		}
+		rank += dir
+		switch {
+		case rank == len(sorted):
+			out.tokens[i] = sorted[0]
+		case rank < 0:
+			out.tokens[i] = sorted[len(sorted)-1]
+		default:
+			out.tokens[i] = sorted[rank]
+			// Validate the untouched prefix so that errors do not depend on
+			// where the carry stops.
+			for j := range i {
+				if _, ok := slices.BinarySearch(sorted, e.tokens[j]); !ok {
+					return EmojiID{}, &TokenError{Index: j, Token: e.tokens[j]}
+				}
+			}
+			return out, nil
+		}
+	}
+
+	if dir > 0 {
+		return EmojiID{}, fmt.Errorf("%w: no ID follows the largest", ErrOverflow)
+	}
+	return EmojiID{}, fmt.Errorf("%w: no ID precedes the smallest", ErrOverflow)
+}
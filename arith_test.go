@@ -0,0 +1,131 @@
+package emojid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// uniformID returns the ID with every token set to r.
+func uniformID(r rune) EmojiID {
+	var id EmojiID
+	for i := range id.tokens {
+		id.tokens[i] = r
+	}
+	return id
+}
+
+func TestNextPrev(t *testing.T) {
+	for range 50 {
+		id := MustNew()
+		next, err := id.Next(DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.Compare(id) <= 0 {
+			t.Fatalf("Next(%v) = %v does not sort after it", id, next)
+		}
+		if back, err := next.Prev(DefaultAlphabet); err != nil || back != id {
+			t.Fatalf("Prev(Next(%v)) = %v, %v", id, back, err)
+		}
+	}
+}
+
+func TestNextCarries(t *testing.T) {
+	sorted := slices.Sorted(slices.Values(testFaces))
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+
+	id := uniformID(lo)
+	id.tokens[TokenCount-2] = hi
+	id.tokens[TokenCount-1] = hi
+	next, err := id.Next(testFaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := uniformID(lo)
+	want.tokens[TokenCount-3] = sorted[1]
+	if next != want {
+		t.Errorf("Next(%v) = %v, want %v", id, next, want)
+	}
+	if prev, err := want.Prev(testFaces); err != nil || prev != id {
+		t.Errorf("Prev(%v) = %v, %v; want %v", want, prev, err, id)
+	}
+}
+
+func TestNextPrevBoundaries(t *testing.T) {
+	sorted := slices.Sorted(slices.Values(testFaces))
+	if _, err := uniformID(sorted[len(sorted)-1]).Next(testFaces); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Next of the largest: got %v, want ErrOverflow", err)
+	}
+	if _, err := uniformID(sorted[0]).Prev(testFaces); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Prev of the smallest: got %v, want ErrOverflow", err)
+	}
+
+	// A foreign token is reported wherever the carry stops.
+	id := uniformID(sorted[0])
+	id.tokens[0] = '🐶'
+	var tokenErr *TokenError
+	if _, err := id.Next(testFaces); !errors.As(err, &tokenErr) || tokenErr.Index != 0 {
+		t.Errorf("foreign first token: got %v, want *TokenError at index 0", err)
+	}
+}
+
+func TestRange(t *testing.T) {
+	from := MustNew()
+	to := from
+	for range 5 {
+		var err error
+		if to, err = to.Next(DefaultAlphabet); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seq, err := Range(from, to, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := slices.Collect(seq)
+	if len(ids) != 6 || ids[0] != from || ids[5] != to {
+		t.Fatalf("Range yielded %d IDs from %v to %v", len(ids), ids[0], ids[len(ids)-1])
+	}
+	if !slices.IsSortedFunc(ids, EmojiID.Compare) {
+		t.Error("Range is not in Compare order")
+	}
+
+	single, err := Range(from, from, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(single); len(got) != 1 || got[0] != from {
+		t.Errorf("single-ID range = %v", got)
+	}
+
+	reversed, err := Range(to, from, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(reversed); len(got) != 0 {
+		t.Errorf("reversed range yielded %d IDs", len(got))
+	}
+
+	// Stopping early is safe.
+	n := 0
+	for range seq {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("stopped after %d IDs, want 2", n)
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	id := MustNew()
+	if _, err := Range(id, id, testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign tokens: got %v, want ErrInvalidToken", err)
+	}
+	if _, err := Range(id, id, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
@@ -0,0 +1,101 @@
+package emojid
+
+import (
+	"fmt"
+	"iter"
+)
+
+// NewBatchUniquePrefix returns n new random IDs from alphabet whose first groups
+// (8 tokens in the standard layout) are all different, so short references to
+// the batch cannot collide. An ID whose prefix repeats an earlier one is redrawn.
+//
+// There are only len(alphabet)^k distinct prefixes of k tokens, so n above that
+// returns ErrConstraintInfeasible. Near the bound the last IDs need many redraws
+// (about keyspace/remaining each); for DefaultAlphabet the bound is 152^8, about
+// 2.8e17, and collisions are vanishingly rare. n below 0 is treated as 0.
+func NewBatchUniquePrefix(n int, alphabet []rune) ([]EmojiID, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+
+	n = max(n, 0)
+
+	k := DefaultLayout()[0]
+	distinct := len(cachedAlphabetSet(alphabet))
+	keyspace := 1
+	for range k {
+		if keyspace >= n {
+			break
+		}
+		keyspace *= distinct
+	}
+	if n > keyspace {
+		return nil, fmt.Errorf("%w: %d IDs exceed the %d possible %d-token prefixes", ErrConstraintInfeasible, n, keyspace, k)
+	}
+
+	ids := make([]EmojiID, 0, n)
+	seen := make(map[string]struct{}, n)
+	for len(ids) < n {
+		id, err := NewWithAlphabet(alphabet)
+		if err != nil {
+			return nil, err
+		}
+		p := string(id.tokens[:k])
+		if _, dup := seen[p]; dup {
+			continue
+		}
+		seen[p] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// NewBatchMap returns n new random IDs from alphabet together with a map from each
+// ID's String form to its index in the slice, for assigning IDs to records. The
+// IDs are guaranteed distinct: a duplicate, which practically never occurs, is
+// redrawn. n below 0 is treated as 0.
+func NewBatchMap(n int, alphabet []rune) ([]EmojiID, map[string]int, error) {
+	if len(alphabet) < 2 {
+		return nil, nil, ErrAlphabetTooSmall
+	}
+	n = max(n, 0)
+
+	ids := make([]EmojiID, 0, n)
+	index := make(map[string]int, n)
+	for len(ids) < n {
+		id, err := NewWithAlphabet(alphabet)
+		if err != nil {
+			return nil, nil, err
+		}
+		s := id.String()
+		if _, dup := index[s]; dup {
+			continue
+		}
+		index[s] = len(ids)
+		ids = append(ids, id)
+	}
+	return ids, index, nil
+}
+
+// Stream returns an iterator that yields new random IDs from alphabet, one per
+// iteration, for range-over-func loops:
+//
+//	for id, err := range emojid.Stream(alphabet) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The sequence is infinite: it only ends when the loop breaks, or after yielding
+// an error, which it does at most once with a zero ID.
+func Stream(alphabet []rune) iter.Seq2[EmojiID, error] {
+	return func(yield func(EmojiID, error) bool) {
+		for {
+			id, err := NewWithAlphabet(alphabet)
+			if !yield(id, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package emojid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewBatchUniquePrefix(t *testing.T) {
+	// Two entries give exactly 2^8 = 256 distinct 8-token prefixes.
+	alphabet := []rune{'🍎', '🍊'}
+	ids, err := NewBatchUniquePrefix(256, alphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 256 {
+		t.Fatalf("got %d IDs, want 256", len(ids))
+	}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		p := string(id.Tokens()[:8])
+		if seen[p] {
+			t.Fatalf("prefix %q repeats", p)
+		}
+		seen[p] = true
+	}
+
+	if _, err := NewBatchUniquePrefix(257, alphabet); !errors.Is(err, ErrConstraintInfeasible) {
+		t.Errorf("257 IDs: got %v, want ErrConstraintInfeasible", err)
+	}
+	// Duplicates do not count towards the keyspace.
+	if _, err := NewBatchUniquePrefix(257, []rune{'🍎', '🍊', '🍎'}); !errors.Is(err, ErrConstraintInfeasible) {
+		t.Errorf("duplicate entries: got %v, want ErrConstraintInfeasible", err)
+	}
+}
+
+func TestNewBatchUniquePrefixEdgeCases(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		ids, err := NewBatchUniquePrefix(n, DefaultAlphabet)
+		if err != nil || len(ids) != 0 {
+			t.Errorf("n=%d: got %d IDs, %v; want none", n, len(ids), err)
+		}
+	}
+	if _, err := NewBatchUniquePrefix(1, []rune{'🍎'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	seen := make(map[EmojiID]bool)
+	for id, err := range Stream(testFaces) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseWithAlphabet(id.String(), testFaces); err != nil {
+			t.Fatal(err)
+		}
+		if seen[id] {
+			t.Fatalf("%v repeated", id)
+		}
+		seen[id] = true
+		if len(seen) == 100 {
+			break
+		}
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	n := 0
+	for id, err := range Stream([]rune{'😀'}) {
+		n++
+		if !errors.Is(err, ErrAlphabetTooSmall) || id != Nil {
+			t.Errorf("got %v, %v; want Nil and ErrAlphabetTooSmall", id, err)
+		}
+	}
+	if n != 1 {
+		t.Errorf("yielded %d times after an error, want 1", n)
+	}
+}
+
+func TestNewBatchMap(t *testing.T) {
+	ids, index, err := NewBatchMap(200, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 200 || len(index) != 200 {
+		t.Fatalf("got %d IDs and %d map entries, want 200 each", len(ids), len(index))
+	}
+	for i, id := range ids {
+		if got, ok := index[id.String()]; !ok || got != i {
+			t.Errorf("index[%v] = %d, %v; want %d", id, got, ok, i)
+		}
+	}
+
+	// Two entries leave few distinct IDs, but all of them are still unique.
+	small, smallIndex, err := NewBatchMap(50, []rune{'🍎', '🍊'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(smallIndex) != len(small) {
+		t.Errorf("%d IDs but %d distinct strings", len(small), len(smallIndex))
+	}
+}
+
+func TestNewBatchMapEdgeCases(t *testing.T) {
+	for _, n := range []int{0, -3} {
+		ids, index, err := NewBatchMap(n, DefaultAlphabet)
+		if err != nil || len(ids) != 0 || len(index) != 0 {
+			t.Errorf("n=%d: got %d IDs, %d entries, %v", n, len(ids), len(index), err)
+		}
+	}
+	if _, _, err := NewBatchMap(1, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
@@ -0,0 +1,58 @@
+package emojid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// bindingTokens is the number of trailing tokens NewBoundTo derives from the
+// payload.
+const bindingTokens = 8
+
+// NewBoundTo returns a new EmojiID bound to payload: the first 24 tokens are
+// random as in NewWithAlphabet, and the last 8 are derived from SHA-256 over
+// those tokens and payload. VerifyBinding later detects an ID stored with a
+// different or altered payload; with DefaultAlphabet a mismatch slips through with
+// probability 152^-8, about 2^-58.
+//
+// This is an integrity check, not confidentiality or authentication: the payload
+// is not hidden, and since no secret key is involved anyone can compute a valid ID
+// for a payload of their choice. Detecting deliberate tampering needs a keyed MAC
+// such as HMAC. The random part carries about 174 bits with DefaultAlphabet.
+func NewBoundTo(payload []byte, alphabet []rune) (EmojiID, error) {
+	id, err := NewWithAlphabet(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	return id.bind(payload, alphabet)
+}
+
+// VerifyBinding reports whether id was produced by NewBoundTo with this payload
+// and alphabet.
+func VerifyBinding(id EmojiID, payload []byte, alphabet []rune) bool {
+	if len(alphabet) < 2 {
+		return false
+	}
+	bound, err := id.bind(payload, alphabet)
+	return err == nil && bound == id
+}
+
+// bind returns a copy of the ID with the binding tokens recomputed for payload.
+func (e EmojiID) bind(payload []byte, alphabet []rune) (EmojiID, error) {
+	h := sha256.New()
+	h.Write([]byte("emojid bind v1\x00"))
+	for _, r := range e.tokens[:TokenCount-bindingTokens] {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(r)))
+	}
+	h.Write(payload)
+
+	s := sampler{r: newKeyStream(h.Sum(nil))}
+	for i := TokenCount - bindingTokens; i < TokenCount; i++ {
+		idx, err := s.index(len(alphabet))
+		if err != nil {
+			return EmojiID{}, err
+		}
+		e.tokens[i] = alphabet[idx]
+	}
+	return e, nil
+}
@@ -0,0 +1,56 @@
+package emojid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewBoundTo(t *testing.T) {
+	payload := []byte(`{"user":42}`)
+	id, err := NewBoundTo(payload, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(id.String()); err != nil {
+		t.Fatalf("bound ID does not parse: %v", err)
+	}
+	if !VerifyBinding(id, payload, DefaultAlphabet) {
+		t.Error("binding does not verify")
+	}
+
+	for _, other := range [][]byte{[]byte(`{"user":43}`), nil, append(payload, ' ')} {
+		if VerifyBinding(id, other, DefaultAlphabet) {
+			t.Errorf("binding verifies with payload %q", other)
+		}
+	}
+
+	tampered := id
+	tampered.tokens[0] = DefaultAlphabet[0]
+	if tampered.tokens[0] == id.tokens[0] {
+		tampered.tokens[0] = DefaultAlphabet[1]
+	}
+	if VerifyBinding(tampered, payload, DefaultAlphabet) {
+		t.Error("binding verifies after the random part changed")
+	}
+	if VerifyBinding(id, payload, []rune{'😀'}) {
+		t.Error("binding verifies with a 1-entry alphabet")
+	}
+}
+
+func TestNewBoundToRandomPart(t *testing.T) {
+	payload := []byte("same")
+	a, err := NewBoundTo(payload, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewBoundTo(payload, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("two IDs bound to the same payload are equal")
+	}
+	if _, err := NewBoundTo(payload, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
@@ -0,0 +1,59 @@
+package emojid
+
+// BloomSet is a fixed-size Bloom filter answering "was this EmojiID probably
+// added before?" without storing the IDs themselves.
+//
+// MayContain never returns false for an added ID. It returns true for an ID
+// that was never added with probability roughly
+//
+//	(1 - e^(-k*n/m))^k
+//
+// for m bits, k hashes and n added IDs. For a target rate p the best choices are
+// m = -n*ln(p)/ln(2)^2 and k = m/n*ln(2): about 9.6 bits per ID and k = 7 for 1%,
+// 14.4 bits per ID and k = 10 for 0.1%. Adding more IDs than the filter was sized
+// for raises the rate quickly.
+//
+// A BloomSet is not safe for concurrent use.
+type BloomSet struct {
+	bits   []uint64
+	m      uint64
+	hashes int
+}
+
+// NewBloomSet returns an empty BloomSet of the given number of bits using the
+// given number of salted Hash64 functions. bits is rounded up to a multiple of
+// 64; values below 64 and hashes below 1 are raised to those minimums.
+func NewBloomSet(bits uint64, hashes int) *BloomSet {
+	if bits < 64 {
+		bits = 64
+	}
+	if hashes < 1 {
+		hashes = 1
+	}
+
+	words := (bits + 63) / 64
+	return &BloomSet{
+		bits:   make([]uint64, words),
+		m:      words * 64,
+		hashes: hashes,
+	}
+}
+
+// Add records id in the set.
+func (b *BloomSet) Add(id EmojiID) {
+	for i := 0; i < b.hashes; i++ {
+		bit := id.hash64(uint64(i)) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MayContain reports whether id was possibly added. A false result is definite.
+func (b *BloomSet) MayContain(id EmojiID) bool {
+	for i := 0; i < b.hashes; i++ {
+		bit := id.hash64(uint64(i)) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package emojid
+
+import "testing"
+
+func TestBloomSetNoFalseNegatives(t *testing.T) {
+	b := NewBloomSet(10_000, 7)
+	ids := make([]EmojiID, 1000)
+	for i := range ids {
+		ids[i] = MustNew()
+		b.Add(ids[i])
+	}
+	for _, id := range ids {
+		if !b.MayContain(id) {
+			t.Fatalf("added ID %v reported absent", id)
+		}
+	}
+}
+
+func TestBloomSetFalsePositiveRate(t *testing.T) {
+	// 9.6 bits per ID and 7 hashes target a 1% false-positive rate.
+	const n = 2000
+	b := NewBloomSet(n*96/10, 7)
+	for range n {
+		b.Add(MustNew())
+	}
+
+	const probes = 20_000
+	fp := 0
+	for range probes {
+		if b.MayContain(MustNew()) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / probes; rate > 0.03 {
+		t.Errorf("false-positive rate %.4f, want about 0.01", rate)
+	}
+}
+
+func TestBloomSetMinimums(t *testing.T) {
+	b := NewBloomSet(0, 0)
+	id := MustNew()
+	if b.MayContain(id) {
+		t.Error("empty set reports an ID")
+	}
+	b.Add(id)
+	if !b.MayContain(id) {
+		t.Error("added ID reported absent")
+	}
+}
+
+func TestHash64Stable(t *testing.T) {
+	id := MustNew()
+	if id.Hash64() != id.Hash64() {
+		t.Error("Hash64 is not deterministic")
+	}
+	if id.Hash64() == MustNew().Hash64() {
+		t.Error("two random IDs share a Hash64")
+	}
+}
@@ -0,0 +1,239 @@
+package emojid
+
+import (
+	"fmt"
+	"slices"
+)
+
+// DefaultCategories maps every DefaultAlphabet emoji to a coarse visual category:
+// "faces", "animals", "nature", "food", "activities", "travel" or "objects".
+var DefaultCategories = func() map[rune]string {
+	m := make(map[rune]string, len(DefaultAlphabet))
+	for _, c := range defaultCategoryTable {
+		for _, r := range c.emoji {
+			m[r] = c.name
+		}
+	}
+	return m
+}()
+
+// category is a named group of emoji.
+type category struct {
+	name  string
+	emoji []rune
+}
+
+// defaultCategoryTable lists the categories in display order.
+var defaultCategoryTable = []category{
+	{"faces", []rune{
+		'😀', '😃', '😄', '😁', '😆', '😅', '😂', '🤣',
+		'😊', '😇', '🙂', '🙃', '😉', '😌', '😍', '🥰',
+		'😘', '😗', '😙', '😚', '😋', '😛', '😝', '😜',
+		'🤪', '🤨', '🧐', '🤓', '😎', '🥳', '😤', '😡',
+		'🤯', '😱', '😴', '🤤', '😷', '🤒', '🤕', '🤠',
+		'😈', '👻', '🤖', '🎃',
+	}},
+	{"animals", []rune{
+		'🐶', '🐱', '🐭', '🐹', '🐰', '🦊', '🐻', '🐼',
+		'🐨', '🐯', '🦁', '🐸', '🐵', '🐔', '🐧', '🐦',
+		'🐤', '🐙', '🦑', '🦀', '🐠', '🐳', '🦋', '🐞',
+	}},
+	{"nature", []rune{
+		'🌸', '🌼', '🌻', '🌺', '🌍', '🌙', '⭐', '⚡',
+		'🔥', '💧', '🌈', '❄',
+	}},
+	{"food", []rune{
+		'🍎', '🍊', '🍋', '🍉', '🍇', '🍓', '🍒', '🍍',
+		'🥑', '🥦', '🥕', '🌶', '🍔', '🍟', '🍕', '🌮',
+		'🍣', '🍩', '🍪', '🍫', '🍿', '☕', '🍺', '🍷',
+	}},
+	{"activities", []rune{
+		'⚽', '🏀', '🏈', '⚾', '🎾', '🏐', '🎱', '🏓',
+		'🎸', '🎹', '🥁', '🎻', '🎧', '🎮', '🧩', '🎲',
+	}},
+	{"travel", []rune{
+		'🚗', '🚕', '🚌', '🚑', '🚒', '🚜', '✈', '🚀',
+		'🛰', '⛵', '🚲', '🛴', '🏠', '🏢', '🏭', '🏰',
+	}},
+	{"objects", []rune{
+		'💎', '🔒', '🔑', '🧠', '💡', '📦', '🧲', '🧰',
+		'🛡', '⚙', '🧪', '🧬', '🔭', '📡', '💾', '🗄',
+	}},
+}
+
+// NewBalanced returns a new random EmojiID from DefaultAlphabet that contains at
+// least one token from each of the DefaultCategories.
+//
+// One randomly chosen position per category is filled from that category and the
+// remaining positions are drawn uniformly from the whole alphabet. The result is
+// not uniform over all balanced IDs, but most random IDs are already balanced and
+// the free positions alone carry more than 180 bits, so the entropy stays close
+// to the 232 bits of New.
+func NewBalanced() (EmojiID, error) {
+	return newBalanced(DefaultAlphabet, DefaultCategories)
+}
+
+// newBalanced guarantees one token from every category that has at least one
+// member in alphabet. Tokens without a category only appear in free positions.
+func newBalanced(alphabet []rune, categories map[rune]string) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	var names []string
+	members := make(map[string][]rune)
+	for _, r := range alphabet {
+		name, ok := categories[r]
+		if !ok {
+			continue
+		}
+		if _, seen := members[name]; !seen {
+			names = append(names, name)
+		}
+		members[name] = append(members[name], r)
+	}
+	if len(names) > TokenCount {
+		return EmojiID{}, fmt.Errorf("%w: %d categories do not fit in %d tokens", ErrConstraintInfeasible, len(names), TokenCount)
+	}
+
+	id, err := NewWithAlphabet(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+
+	// Partial Fisher-Yates shuffle: positions[:len(names)] become the reserved slots.
+	var positions [TokenCount]int
+	for i := range positions {
+		positions[i] = i
+	}
+	for i, name := range names {
+		j, err := cryptoRandIndex(TokenCount - i)
+		if err != nil {
+			return EmojiID{}, err
+		}
+		positions[i], positions[i+j] = positions[i+j], positions[i]
+
+		group := members[name]
+		idx, err := cryptoRandIndex(len(group))
+		if err != nil {
+			return EmojiID{}, err
+		}
+		id.tokens[positions[i]] = group[idx]
+	}
+
+	return id, nil
+}
+
+// NewOrdered returns a new random EmojiID from alphabet whose tokens are sorted by
+// category (in DefaultCategories order: faces, animals, nature, food, activities,
+// travel, objects) and then by code point, which makes an ID easier to read out
+// loud. Tokens without a category sort last. Parsing is unaffected.
+//
+// Sorting discards the order of the draws, so only the multiset of tokens is
+// random. That roughly halves the entropy: at most about 119 bits for
+// DefaultAlphabet instead of 232. Use a larger alphabet or New where that matters.
+func NewOrdered(alphabet []rune) (EmojiID, error) {
+	id, err := NewWithAlphabet(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+
+	slices.SortFunc(id.tokens[:], func(a, b rune) int {
+		if c := categoryRank(a) - categoryRank(b); c != 0 {
+			return c
+		}
+		return int(a - b)
+	})
+	return id, nil
+}
+
+// categoryRank returns the position of r's category in defaultCategoryTable, or
+// len(defaultCategoryTable) for tokens without a category.
+func categoryRank(r rune) int {
+	name, ok := DefaultCategories[r]
+	if !ok {
+		return len(defaultCategoryTable)
+	}
+	return slices.IndexFunc(defaultCategoryTable, func(c category) bool { return c.name == name })
+}
+
+// NewMaxVariety returns a new random EmojiID from alphabet whose tokens spread
+// over the categories as evenly as possible, for maximum visual variety. With at
+// least 32 categories every token comes from a different one; with C < 32
+// categories each appears floor(32/C) or ceil(32/C) times. Entries of alphabet
+// missing from categories share a single unnamed category.
+//
+// The category of each position is fixed first, by a random arrangement of the
+// balanced category counts, and each token is then drawn uniformly from its
+// category. A token therefore carries log2 of its category size rather than
+// log2(len(alphabet)) bits, plus a share of the arrangement: about 219 bits for
+// DefaultAlphabet with DefaultCategories, against 232 bits for New. Small
+// categories cost the most, since their members are drawn as often as those of
+// large ones.
+func NewMaxVariety(alphabet []rune, categories map[rune]string) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	var names []string
+	members := make(map[string][]rune)
+	for _, r := range alphabet {
+		name := categories[r]
+		if _, seen := members[name]; !seen {
+			names = append(names, name)
+		}
+		members[name] = append(members[name], r)
+	}
+
+	// Every category once per full round, then a random subset for the remainder.
+	var slots []string
+	for range TokenCount / len(names) {
+		slots = append(slots, names...)
+	}
+	for i := range TokenCount % len(names) {
+		j, err := cryptoRandIndex(len(names) - i)
+		if err != nil {
+			return EmojiID{}, err
+		}
+		names[i], names[i+j] = names[i+j], names[i]
+		slots = append(slots, names[i])
+	}
+
+	var id EmojiID
+	for i := range id.tokens {
+		// Fisher-Yates over the slots, drawing each token as its slot is placed.
+		j, err := cryptoRandIndex(TokenCount - i)
+		if err != nil {
+			return EmojiID{}, err
+		}
+		slots[i], slots[i+j] = slots[i+j], slots[i]
+
+		group := members[slots[i]]
+		idx, err := cryptoRandIndex(len(group))
+		if err != nil {
+			return EmojiID{}, err
+		}
+		id.tokens[i] = group[idx]
+	}
+	return id, nil
+}
+
+// SameCategoryPattern reports whether e and other have tokens of the same category
+// at every position, e.g. face-animal-food-..., even where the emoji differ; pass
+// DefaultCategories or a custom mapping. A token missing from categories has no
+// category and only matches the identical token, so unknown emoji are not lumped
+// together into one wildcard class.
+func (e EmojiID) SameCategoryPattern(other EmojiID, categories map[rune]string) bool {
+	for i, a := range e.tokens {
+		b := other.tokens[i]
+		if a == b {
+			continue
+		}
+		ca, okA := categories[a]
+		cb, okB := categories[b]
+		if !okA || !okB || ca != cb {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package emojid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDefaultCategoriesCoverDefaultAlphabet(t *testing.T) {
+	if len(DefaultCategories) != len(DefaultAlphabet) {
+		t.Errorf("%d categorized emoji, want %d", len(DefaultCategories), len(DefaultAlphabet))
+	}
+	for _, r := range DefaultAlphabet {
+		if _, ok := DefaultCategories[r]; !ok {
+			t.Errorf("%q has no category", string(r))
+		}
+	}
+}
+
+func TestNewBalanced(t *testing.T) {
+	for range 200 {
+		id, err := NewBalanced()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[string]bool)
+		for _, r := range id.Tokens() {
+			seen[DefaultCategories[r]] = true
+		}
+		for _, c := range defaultCategoryTable {
+			if !seen[c.name] {
+				t.Fatalf("%v has no %s token", id, c.name)
+			}
+		}
+	}
+}
+
+func TestNewOrdered(t *testing.T) {
+	for range 100 {
+		id, err := NewOrdered(DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens := id.Tokens()
+		for i := 1; i < len(tokens); i++ {
+			if categoryRank(tokens[i-1]) > categoryRank(tokens[i]) {
+				t.Fatalf("%v: %q sorts after %q", id, string(tokens[i-1]), string(tokens[i]))
+			}
+		}
+	}
+}
+
+func TestNewMaxVariety(t *testing.T) {
+	// Seven categories: each appears floor(32/7) = 4 or 5 times.
+	for range 100 {
+		id, err := NewMaxVariety(DefaultAlphabet, DefaultCategories)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := make(map[string]int)
+		for _, r := range id.Tokens() {
+			counts[DefaultCategories[r]]++
+		}
+		if len(counts) != len(defaultCategoryTable) {
+			t.Fatalf("%v covers %d categories, want %d", id, len(counts), len(defaultCategoryTable))
+		}
+		for name, n := range counts {
+			if n < 4 || n > 5 {
+				t.Fatalf("%v has %d %s tokens, want 4 or 5", id, n, name)
+			}
+		}
+	}
+}
+
+func TestNewMaxVarietyManyCategories(t *testing.T) {
+	// One category per entry and more entries than tokens: all tokens differ.
+	categories := make(map[rune]string)
+	for _, r := range DefaultAlphabet[:40] {
+		categories[r] = string(r)
+	}
+	id, err := NewMaxVariety(DefaultAlphabet[:40], categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[rune]bool)
+	for _, r := range id.Tokens() {
+		if seen[r] {
+			t.Fatalf("%v repeats %q", id, string(r))
+		}
+		seen[r] = true
+	}
+}
+
+func TestNewMaxVarietyUncategorized(t *testing.T) {
+	// testAnimals has no categories here, so it forms one unnamed category
+	// alongside "faces": 16 tokens each.
+	categories := make(map[rune]string)
+	for _, r := range testFaces {
+		categories[r] = "faces"
+	}
+	id, err := NewMaxVariety(append(slices.Clone(testFaces), testAnimals...), categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	faces := 0
+	for _, r := range id.Tokens() {
+		if slices.Contains(testFaces, r) {
+			faces++
+		}
+	}
+	if faces != TokenCount/2 {
+		t.Errorf("%v has %d faces, want %d", id, faces, TokenCount/2)
+	}
+}
+
+func TestSameCategoryPattern(t *testing.T) {
+	// Faces and animals alternate in both IDs, with different emoji.
+	var a, b EmojiID
+	for i := range a.tokens {
+		if i%2 == 0 {
+			a.tokens[i], b.tokens[i] = testFaces[i%8], testFaces[(i+3)%8]
+		} else {
+			a.tokens[i], b.tokens[i] = testAnimals[i%8], testAnimals[(i+5)%8]
+		}
+	}
+	if !a.SameCategoryPattern(b, DefaultCategories) {
+		t.Error("alternating face/animal IDs do not match")
+	}
+	if !a.SameCategoryPattern(a, nil) {
+		t.Error("an ID does not match itself without categories")
+	}
+
+	c := b
+	c.tokens[4] = '🍕'
+	if a.SameCategoryPattern(c, DefaultCategories) {
+		t.Error("food in a face position matches")
+	}
+
+	// Unknown tokens only match themselves.
+	d, e := a, a
+	d.tokens[0], e.tokens[0] = '🕐', '🕑'
+	if d.SameCategoryPattern(e, DefaultCategories) {
+		t.Error("two uncategorized tokens match")
+	}
+	if !d.SameCategoryPattern(d, DefaultCategories) {
+		t.Error("an uncategorized token does not match itself")
+	}
+}
@@ -0,0 +1,113 @@
+package emojid
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultChecksumDelimiter separates the ID from its check token in
+// StringWithChecksum and ParseWithChecksum.
+const DefaultChecksumDelimiter = '#'
+
+// StringWithChecksum formats the EmojiID followed by DefaultChecksumDelimiter and
+// a check token from alphabet: "<id>#<check>". The check token catches any single
+// mistyped token and most swaps of adjacent tokens.
+func (e EmojiID) StringWithChecksum(alphabet []rune) (string, error) {
+	return e.FormatWithChecksum(DefaultChecksumDelimiter, alphabet)
+}
+
+// FormatWithChecksum is like StringWithChecksum with a custom delimiter, which
+// must not be a token of alphabet.
+func (e EmojiID) FormatWithChecksum(delim rune, alphabet []rune) (string, error) {
+	if err := checkChecksumDelimiter(delim, alphabet); err != nil {
+		return "", err
+	}
+
+	check, err := e.checkToken(alphabet)
+	if err != nil {
+		return "", err
+	}
+	return e.String() + string(delim) + string(check), nil
+}
+
+// Checksum returns the check token that StringWithChecksum would append, for
+// callers that store it in a separate field. Every token must be in alphabet.
+func (e EmojiID) Checksum(alphabet []rune) (rune, error) {
+	if len(alphabet) < 2 {
+		return 0, ErrAlphabetTooSmall
+	}
+	return e.checkToken(alphabet)
+}
+
+// VerifyChecksumToken reports whether check is the ID's check token for alphabet,
+// as returned by Checksum.
+func (e EmojiID) VerifyChecksumToken(check rune, alphabet []rune) bool {
+	want, err := e.Checksum(alphabet)
+	return err == nil && check == want
+}
+
+// ParseWithChecksum parses "<id>#<check>" as produced by StringWithChecksum and
+// returns ErrChecksumMismatch if the check token does not match the ID.
+func ParseWithChecksum(s string, alphabet []rune) (EmojiID, error) {
+	return ParseWithChecksumDelimiter(s, DefaultChecksumDelimiter, alphabet)
+}
+
+// ParseWithChecksumDelimiter is like ParseWithChecksum with a custom delimiter,
+// which must not be a token of alphabet.
+func ParseWithChecksumDelimiter(s string, delim rune, alphabet []rune) (EmojiID, error) {
+	if err := checkChecksumDelimiter(delim, alphabet); err != nil {
+		return EmojiID{}, err
+	}
+
+	i := strings.LastIndex(s, string(delim))
+	if i < 0 {
+		return EmojiID{}, fmt.Errorf("%w: missing checksum delimiter %q", ErrInvalidFormat, delim)
+	}
+	body, check := s[:i], s[i+utf8.RuneLen(delim):]
+	if utf8.RuneCountInString(check) != 1 {
+		return EmojiID{}, fmt.Errorf("%w: checksum must be a single token", ErrInvalidFormat)
+	}
+
+	id, err := ParseWithAlphabet(body, alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+
+	want, err := id.checkToken(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	if got, _ := utf8.DecodeRuneInString(check); got != want {
+		return EmojiID{}, ErrChecksumMismatch
+	}
+	return id, nil
+}
+
+func checkChecksumDelimiter(delim rune, alphabet []rune) error {
+	if len(alphabet) < 2 {
+		return ErrAlphabetTooSmall
+	}
+	if _, ok := cachedAlphabetSet(alphabet)[delim]; ok || delim == '-' || !utf8.ValidRune(delim) {
+		return fmt.Errorf("%w: %q", ErrInvalidSeparator, delim)
+	}
+	return nil
+}
+
+// checkToken computes the Luhn mod N check token over the token indices.
+func (e EmojiID) checkToken(alphabet []rune) (rune, error) {
+	indices, err := e.Indices(alphabet)
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(alphabet)
+	factor, sum := 2, 0
+	for i := len(indices) - 1; i >= 0; i-- {
+		addend := factor * indices[i]
+		addend = addend/n + addend%n
+		sum += addend
+		factor = 3 - factor
+	}
+	return alphabet[(n-sum%n)%n], nil
+}
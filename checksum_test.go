@@ -0,0 +1,113 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChecksumRoundTrip(t *testing.T) {
+	id := MustNew()
+	s, err := id.StringWithChecksum(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(s, id.String()+"#") {
+		t.Errorf("StringWithChecksum() = %q", s)
+	}
+	got, err := ParseWithChecksum(s, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("round trip = %v, want %v", got, id)
+	}
+
+	s, err = id.FormatWithChecksum('|', DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ParseWithChecksumDelimiter(s, '|', DefaultAlphabet); err != nil || got != id {
+		t.Errorf("custom delimiter round trip = %v, %v; want %v", got, err, id)
+	}
+}
+
+func TestChecksumDetectsSingleSubstitution(t *testing.T) {
+	id := MustNew()
+	check, err := id.checkToken(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range TokenCount {
+		mistyped := id
+		for _, r := range DefaultAlphabet {
+			if r != id.tokens[i] {
+				mistyped.tokens[i] = r
+				break
+			}
+		}
+		s := mistyped.String() + "#" + string(check)
+		if _, err := ParseWithChecksum(s, DefaultAlphabet); !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("mistyped token %d: got %v, want ErrChecksumMismatch", i, err)
+		}
+	}
+}
+
+func TestParseWithChecksumErrors(t *testing.T) {
+	id := MustNew()
+	s, err := id.StringWithChecksum(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		in   string
+		want error
+	}{
+		{id.String(), ErrInvalidFormat},
+		{s + "😀", ErrInvalidFormat},
+		{strings.TrimSuffix(s, string([]rune(s)[len([]rune(s))-1])), ErrInvalidFormat},
+	} {
+		if _, err := ParseWithChecksum(tc.in, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("ParseWithChecksum(%q): got %v, want %v", tc.in, err, tc.want)
+		}
+	}
+
+	for _, delim := range []rune{'-', '😀'} {
+		if _, err := id.FormatWithChecksum(delim, DefaultAlphabet); !errors.Is(err, ErrInvalidSeparator) {
+			t.Errorf("delimiter %q: got %v, want ErrInvalidSeparator", delim, err)
+		}
+	}
+	if _, err := id.StringWithChecksum(testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign alphabet: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestChecksumToken(t *testing.T) {
+	id := MustNew()
+	check, err := id.Checksum(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := id.StringWithChecksum(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(s, "#"+string(check)) {
+		t.Errorf("Checksum() = %q, but StringWithChecksum() = %q", string(check), s)
+	}
+	if !id.VerifyChecksumToken(check, DefaultAlphabet) {
+		t.Error("own check token does not verify")
+	}
+
+	for _, r := range DefaultAlphabet {
+		if r != check && id.VerifyChecksumToken(r, DefaultAlphabet) {
+			t.Errorf("wrong token %q verifies", string(r))
+		}
+	}
+	if id.VerifyChecksumToken(check, testFaces) {
+		t.Error("check token verifies with a foreign alphabet")
+	}
+	if _, err := id.Checksum([]rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
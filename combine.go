@@ -0,0 +1,55 @@
+package emojid
+
+// Interleave combines e and other into a single ID over the default alphabet, e.g.
+// a user ID and a device ID into one composite key. See InterleaveWithAlphabet.
+func (e EmojiID) Interleave(other EmojiID) (EmojiID, error) {
+	return e.InterleaveWithAlphabet(other, defaultAlphabet())
+}
+
+// InterleaveWithAlphabet combines e and other position by position: token i of the
+// result is the alphabet entry at (index(e[i]) + index(other[i])) mod len(alphabet).
+// Both IDs must come from the same alphabet, and the result is a valid ID from it.
+//
+// The combination is deterministic but not symmetric in what it reveals: knowing
+// the result and one operand recovers the other with DeinterleaveWithAlphabet,
+// while the result alone reveals neither. Exact recovery needs an alphabet
+// without duplicate entries.
+func (e EmojiID) InterleaveWithAlphabet(other EmojiID, alphabet []rune) (EmojiID, error) {
+	return combineIndices(e, other, alphabet, 1)
+}
+
+// Deinterleave recovers the other operand of Interleave given its result e and
+// the known operand, over the default alphabet.
+func (e EmojiID) Deinterleave(known EmojiID) (EmojiID, error) {
+	return e.DeinterleaveWithAlphabet(known, defaultAlphabet())
+}
+
+// DeinterleaveWithAlphabet reverses InterleaveWithAlphabet: for c = a
+// interleaved with b, c.DeinterleaveWithAlphabet(a, alphabet) returns b and
+// c.DeinterleaveWithAlphabet(b, alphabet) returns a.
+func (e EmojiID) DeinterleaveWithAlphabet(known EmojiID, alphabet []rune) (EmojiID, error) {
+	return combineIndices(e, known, alphabet, -1)
+}
+
+// combineIndices returns the ID whose indices are a + sign*b modulo the alphabet
+// size.
+func combineIndices(a, b EmojiID, alphabet []rune, sign int) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+	ai, err := a.Indices(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	bi, err := b.Indices(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+
+	n := len(alphabet)
+	var id EmojiID
+	for i := range id.tokens {
+		id.tokens[i] = alphabet[((ai[i]+sign*bi[i])%n+n)%n]
+	}
+	return id, nil
+}
@@ -0,0 +1,55 @@
+package emojid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInterleaveRoundTrip(t *testing.T) {
+	for range 20 {
+		a, b := MustNew(), MustNew()
+		c, err := a.Interleave(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Parse(c.String()); err != nil {
+			t.Fatalf("interleaved ID does not parse: %v", err)
+		}
+		if got, err := c.Deinterleave(a); err != nil || got != b {
+			t.Errorf("Deinterleave(a) = %v, %v; want %v", got, err, b)
+		}
+		if got, err := c.Deinterleave(b); err != nil || got != a {
+			t.Errorf("Deinterleave(b) = %v, %v; want %v", got, err, a)
+		}
+	}
+
+	a, err := NewWithAlphabet(testFaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewWithAlphabet(testFaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := a.InterleaveWithAlphabet(b, testFaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.DeinterleaveWithAlphabet(a, testFaces); err != nil || got != b {
+		t.Errorf("custom alphabet: got %v, %v; want %v", got, err, b)
+	}
+}
+
+func TestInterleaveErrors(t *testing.T) {
+	a := MustNew()
+	b, err := NewWithAlphabet(testAnimals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.InterleaveWithAlphabet(b, testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign tokens: got %v, want ErrInvalidToken", err)
+	}
+	if _, err := a.InterleaveWithAlphabet(a, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
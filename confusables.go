@@ -0,0 +1,61 @@
+package emojid
+
+// confusables maps look-alikes of DefaultAlphabet members to the member they can
+// be mistaken for. Unicode's confusables.txt barely covers pictographs, so the
+// table is curated by hand from variants that are hard to tell apart at text size:
+// globe and moon phases, animal face versus full body, car and house variants,
+// and the text symbols that resemble an emoji (★ for ⭐, ❅ for ❄).
+var confusables = map[rune]rune{
+	'🌎': '🌍', '🌏': '🌍', '🌐': '🌍',
+	'🌛': '🌙', '🌜': '🌙', '☽': '🌙', '☾': '🌙',
+	'🌟': '⭐', '★': '⭐', '☆': '⭐',
+	'🗲': '⚡',
+	'💦': '💧',
+	'❅': '❄', '❆': '❄',
+	'🔐': '🔒', '🔏': '🔒',
+	'🗝': '🔑',
+	'💽': '💾',
+	'🚙': '🚗', '🚘': '🚗',
+	'🚖': '🚕',
+	'🚍': '🚌',
+	'🛵': '🛴',
+	'🛩': '✈',
+	'🏡': '🏠', '⌂': '🏠',
+	'🏬': '🏢', '🏣': '🏢',
+	'🐕': '🐶', '🐈': '🐱', '🐁': '🐭', '🐇': '🐰', '🐅': '🐯',
+	'🐒': '🐵', '🐓': '🐔', '🐥': '🐤', '🐣': '🐤',
+	'🐋': '🐳', '🐟': '🐠',
+	'🍏': '🍎',
+	'🍻': '🍺',
+	'🥎': '🎾',
+	'💮': '🌸',
+	'☺': '🙂',
+	'👿': '😈',
+}
+
+// ContainsConfusable reports whether s uses a known look-alike in place of an
+// alphabet member, which may indicate a spoofed ID, and returns the token
+// positions (0-based, not counting '-' separators or presentation selectors)
+// where that happens. A look-alike is only flagged when it is not itself in
+// alphabet and the emoji it imitates is. Call it before Parse, which only reports
+// the first invalid token and does not say what it resembles.
+func ContainsConfusable(s string, alphabet []rune) (bool, []int) {
+	allowed := cachedAlphabetSet(alphabet)
+
+	var positions []int
+	i := 0
+	for _, r := range s {
+		if r == '-' || isPresentationSelector(r) {
+			continue
+		}
+		if _, ok := allowed[r]; !ok {
+			if target, ok := confusables[r]; ok {
+				if _, ok := allowed[target]; ok {
+					positions = append(positions, i)
+				}
+			}
+		}
+		i++
+	}
+	return len(positions) > 0, positions
+}
@@ -0,0 +1,45 @@
+package emojid
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestContainsConfusable(t *testing.T) {
+	id := MustNew()
+	if found, pos := ContainsConfusable(id.String(), DefaultAlphabet); found || pos != nil {
+		t.Errorf("genuine ID flagged at %v", pos)
+	}
+
+	// Replace tokens 0 and 9 (after the first dash) with look-alikes.
+	r := []rune(id.String())
+	r[0], r[10] = '🌎', '🐕'
+	found, pos := ContainsConfusable(string(r), DefaultAlphabet)
+	if !found || !slices.Equal(pos, []int{0, 9}) {
+		t.Errorf("got %v, %v; want true, [0 9]", found, pos)
+	}
+
+	// Presentation selectors do not shift positions.
+	withSelector := strings.Replace(string(r), "🌎", "🌎\uFE0F", 1)
+	if _, pos := ContainsConfusable(withSelector, DefaultAlphabet); !slices.Equal(pos, []int{0, 9}) {
+		t.Errorf("with selector: positions %v, want [0 9]", pos)
+	}
+}
+
+func TestContainsConfusableAlphabetAware(t *testing.T) {
+	s := strings.Repeat("🐕", TokenCount)
+	// The look-alike is itself allowed.
+	if found, _ := ContainsConfusable(s, []rune{'🐕', '🐶'}); found {
+		t.Error("allowed look-alike flagged")
+	}
+	// The imitated emoji is not in the alphabet.
+	if found, _ := ContainsConfusable(s, testFaces); found {
+		t.Error("look-alike of a foreign emoji flagged")
+	}
+	for from, to := range confusables {
+		if !slices.Contains(DefaultAlphabet, to) || slices.Contains(DefaultAlphabet, from) {
+			t.Errorf("confusables[%q] = %q is not a look-alike of a DefaultAlphabet member", string(from), string(to))
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package emojid
+
+import "fmt"
+
+// NewWithMaxRepeats returns a new random EmojiID from alphabet in which no token
+// appears more than k times, for visual balance.
+//
+// A token that would exceed the limit is redrawn, so each position is uniform over
+// the tokens still allowed at that point. The constraint is only feasible when the
+// alphabet has at least ceil(32/k) distinct entries; otherwise
+// ErrConstraintInfeasible is returned. The entropy cost is negligible for
+// DefaultAlphabet with k >= 2 and grows as the alphabet approaches that bound,
+// where the last positions are left with few choices.
+func NewWithMaxRepeats(k int, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	distinct := len(cachedAlphabetSet(alphabet))
+	if k < 1 || distinct*k < TokenCount {
+		return EmojiID{}, fmt.Errorf("%w: %d distinct tokens cannot fill %d positions at most %d times each",
+			ErrConstraintInfeasible, distinct, TokenCount, k)
+	}
+
+	var id EmojiID
+	counts := make(map[rune]int, TokenCount)
+	for i := range id.tokens {
+		for {
+			idx, err := cryptoRandIndex(len(alphabet))
+			if err != nil {
+				return EmojiID{}, err
+			}
+			r := alphabet[idx]
+			if counts[r] < k {
+				counts[r]++
+				id.tokens[i] = r
+				break
+			}
+		}
+	}
+	return id, nil
+}
+
+// NewWithMarkers returns a new random EmojiID from alphabet with the positions in
+// markers fixed to the given tokens, e.g. {0: start, 31: end} for framing, and
+// every other position random. Each position must be in [0, 32) and each marker
+// must be in alphabet.
+func NewWithMarkers(markers map[int]rune, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	allowed := cachedAlphabetSet(alphabet)
+	for i, r := range markers {
+		if i < 0 || i >= TokenCount {
+			return EmojiID{}, fmt.Errorf("%w: marker position %d", ErrIndexOutOfRange, i)
+		}
+		if _, ok := allowed[r]; !ok {
+			return EmojiID{}, &TokenError{Index: i, Token: r}
+		}
+	}
+
+	id, err := NewWithAlphabet(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	for i, r := range markers {
+		id.tokens[i] = r
+	}
+	return id, nil
+}
+
+// ReRandomizeSuffix returns a copy of the ID with the first keep tokens preserved
+// and the rest drawn afresh from alphabet, e.g. to rotate a key while its visible
+// prefix stays stable. keep must be in [0, 32]; the new ID has the entropy of the
+// TokenCount-keep fresh tokens only.
+func (e EmojiID) ReRandomizeSuffix(keep int, alphabet []rune) (EmojiID, error) {
+	if keep < 0 || keep > TokenCount {
+		return EmojiID{}, fmt.Errorf("%w: keep %d", ErrIndexOutOfRange, keep)
+	}
+
+	fresh, err := NewWithAlphabet(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	copy(fresh.tokens[:keep], e.tokens[:keep])
+	return fresh, nil
+}
+
+// spreadThreshold is the similarity at or above which NewVisuallySpread treats
+// two neighbouring tokens as too alike.
+const spreadThreshold = 0.5
+
+// CategorySimilarity is the default similarity for NewVisuallySpread: 1 for two
+// tokens in the same DefaultCategories category or for identical tokens, else 0.
+func CategorySimilarity(a, b rune) float64 {
+	if a == b {
+		return 1
+	}
+	ca, okA := DefaultCategories[a]
+	cb, okB := DefaultCategories[b]
+	if okA && okB && ca == cb {
+		return 1
+	}
+	return 0
+}
+
+// NewVisuallySpread returns a new random EmojiID from alphabet in which no two
+// adjacent tokens look alike, e.g. two yellow faces in a row, for readability.
+// similarity scores a pair of tokens from 0 (distinct) to 1 (alike); each token
+// is drawn uniformly from the entries scoring below 0.5 against its predecessor.
+// A nil similarity uses CategorySimilarity. If some entry has no sufficiently
+// different entry to follow it, ErrConstraintInfeasible is returned.
+//
+// Each token after the first carries log2 of the number of allowed followers
+// instead of log2(len(alphabet)) bits: about 223 bits in total for
+// DefaultAlphabet with CategorySimilarity, against 232 for New. similarity is
+// called up to len(alphabet) times per token.
+func NewVisuallySpread(alphabet []rune, similarity func(a, b rune) float64) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+	if similarity == nil {
+		similarity = CategorySimilarity
+	}
+
+	var id EmojiID
+	idx, err := cryptoRandIndex(len(alphabet))
+	if err != nil {
+		return EmojiID{}, err
+	}
+	id.tokens[0] = alphabet[idx]
+
+	followers := make([]rune, 0, len(alphabet))
+	for i := 1; i < TokenCount; i++ {
+		prev := id.tokens[i-1]
+		followers = followers[:0]
+		for _, r := range alphabet {
+			if similarity(prev, r) < spreadThreshold {
+				followers = append(followers, r)
+			}
+		}
+		if len(followers) == 0 {
+			return EmojiID{}, fmt.Errorf("%w: no token is different enough to follow %q", ErrConstraintInfeasible, string(prev))
+		}
+
+		idx, err := cryptoRandIndex(len(followers))
+		if err != nil {
+			return EmojiID{}, err
+		}
+		id.tokens[i] = followers[idx]
+	}
+	return id, nil
+}
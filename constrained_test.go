@@ -0,0 +1,146 @@
+package emojid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNewWithMaxRepeats(t *testing.T) {
+	// 16 distinct tokens at most twice each is exactly enough for 32 positions.
+	alphabet := DefaultAlphabet[:16]
+	for range 50 {
+		id, err := NewWithMaxRepeats(2, alphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := make(map[rune]int)
+		for _, r := range id.Tokens() {
+			counts[r]++
+			if counts[r] > 2 {
+				t.Fatalf("%v uses %q more than twice", id, string(r))
+			}
+		}
+	}
+}
+
+func TestNewWithMaxRepeatsInfeasible(t *testing.T) {
+	for _, k := range []int{0, 1, 10} {
+		if _, err := NewWithMaxRepeats(k, []rune{'🍎', '🍊', '🍋'}); !errors.Is(err, ErrConstraintInfeasible) {
+			t.Errorf("k=%d with 3 tokens: got %v, want ErrConstraintInfeasible", k, err)
+		}
+	}
+	if _, err := NewWithMaxRepeats(2, []rune{'🍎'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 token: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestNewWithMarkers(t *testing.T) {
+	markers := map[int]rune{0: '🚀', 15: '🔑', TokenCount - 1: '🏁'}
+	alphabet := append([]rune{'🏁'}, DefaultAlphabet...)
+	for range 20 {
+		id, err := NewWithMarkers(markers, alphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, r := range markers {
+			if id.tokens[i] != r {
+				t.Fatalf("token %d = %q, want %q", i, string(id.tokens[i]), string(r))
+			}
+		}
+		if _, err := ParseWithAlphabet(id.String(), alphabet); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNewWithMarkersErrors(t *testing.T) {
+	for _, i := range []int{-1, TokenCount} {
+		if _, err := NewWithMarkers(map[int]rune{i: '😀'}, DefaultAlphabet); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("position %d: got %v, want ErrIndexOutOfRange", i, err)
+		}
+	}
+	_, err := NewWithMarkers(map[int]rune{3: '🏁'}, DefaultAlphabet)
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) || tokenErr.Index != 3 {
+		t.Errorf("marker outside alphabet: got %v, want *TokenError at index 3", err)
+	}
+}
+
+func TestReRandomizeSuffix(t *testing.T) {
+	id := MustNew()
+	for _, keep := range []int{0, 8, 31, TokenCount} {
+		got, err := id.ReRandomizeSuffix(keep, DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.tokens[:keep], id.tokens[:keep]) {
+			t.Errorf("keep %d: prefix changed from %v to %v", keep, id, got)
+		}
+		if keep <= 24 && slices.Equal(got.tokens[keep:], id.tokens[keep:]) {
+			t.Errorf("keep %d: suffix of %v was not redrawn", keep, id)
+		}
+	}
+
+	faces, err := id.ReRandomizeSuffix(0, testFaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseWithAlphabet(faces.String(), testFaces); err != nil {
+		t.Errorf("suffix not drawn from alphabet: %v", err)
+	}
+}
+
+func TestReRandomizeSuffixErrors(t *testing.T) {
+	id := MustNew()
+	for _, keep := range []int{-1, TokenCount + 1} {
+		if _, err := id.ReRandomizeSuffix(keep, DefaultAlphabet); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("keep %d: got %v, want ErrIndexOutOfRange", keep, err)
+		}
+	}
+	if _, err := id.ReRandomizeSuffix(8, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestNewVisuallySpread(t *testing.T) {
+	for range 100 {
+		id, err := NewVisuallySpread(DefaultAlphabet, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < TokenCount; i++ {
+			a, b := id.tokens[i-1], id.tokens[i]
+			if DefaultCategories[a] == DefaultCategories[b] {
+				t.Fatalf("%v has adjacent %s tokens at %d", id, DefaultCategories[a], i)
+			}
+		}
+	}
+
+	// A custom similarity that forbids neighbouring code points.
+	near := func(a, b rune) float64 {
+		if d := a - b; d >= -1 && d <= 1 {
+			return 1
+		}
+		return 0
+	}
+	id, err := NewVisuallySpread(testFaces, near)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < TokenCount; i++ {
+		if near(id.tokens[i-1], id.tokens[i]) >= 0.5 {
+			t.Fatalf("%v has similar neighbours at %d", id, i)
+		}
+	}
+}
+
+func TestNewVisuallySpreadInfeasible(t *testing.T) {
+	// Every entry is in the same category, so nothing may follow anything.
+	if _, err := NewVisuallySpread(testFaces, nil); !errors.Is(err, ErrConstraintInfeasible) {
+		t.Errorf("single category: got %v, want ErrConstraintInfeasible", err)
+	}
+	if _, err := NewVisuallySpread([]rune{'😀'}, nil); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
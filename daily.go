@@ -0,0 +1,54 @@
+package emojid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"time"
+)
+
+// dailyPrefixTokens is the length of the date-derived prefix of NewDaily.
+const dailyPrefixTokens = 8
+
+// NewDaily returns a new EmojiID whose first 8 tokens are derived from
+// HMAC-SHA256(key, current UTC date as "2006-01-02") and whose other 24 tokens
+// are random, for daily-rotating display codes: every ID issued on the same UTC
+// day with the same key shares its prefix, which changes at midnight UTC. Without
+// the key the prefix of a future day cannot be predicted.
+//
+// Only the 24 random tokens distinguish IDs of one day: about 174 bits with
+// DefaultAlphabet. DailyPrefix returns the prefix for any day.
+func NewDaily(key []byte, alphabet []rune) (EmojiID, error) {
+	prefix, err := DailyPrefix(key, time.Now(), alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+
+	id, err := NewWithAlphabet(alphabet)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	copy(id.tokens[:], prefix)
+	return id, nil
+}
+
+// DailyPrefix returns the 8-token prefix NewDaily uses on the UTC date of day,
+// e.g. to check which day an ID was issued or to group IDs by day.
+func DailyPrefix(key []byte, day time.Time, alphabet []rune) ([]rune, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(day.UTC().Format(time.DateOnly)))
+
+	s := sampler{r: newKeyStream(mac.Sum(nil))}
+	prefix := make([]rune, dailyPrefixTokens)
+	for i := range prefix {
+		idx, err := s.index(len(alphabet))
+		if err != nil {
+			return nil, err
+		}
+		prefix[i] = alphabet[idx]
+	}
+	return prefix, nil
+}
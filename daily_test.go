@@ -0,0 +1,77 @@
+package emojid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewDaily(t *testing.T) {
+	key := []byte("daily key")
+	before := time.Now()
+	a, err := NewDaily(key, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewDaily(key, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now()
+
+	// Accept either day should the calls straddle midnight UTC.
+	matches := func(id EmojiID) bool {
+		for _, day := range []time.Time{before, after} {
+			p, err := DailyPrefix(key, day, DefaultAlphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if slices.Equal(id.tokens[:dailyPrefixTokens], p) {
+				return true
+			}
+		}
+		return false
+	}
+	if !matches(a) || !matches(b) {
+		t.Errorf("%v and %v do not start with today's prefix", a, b)
+	}
+	if a == b {
+		t.Error("two daily IDs are equal")
+	}
+}
+
+func TestDailyPrefix(t *testing.T) {
+	key := []byte("daily key")
+	morning := time.Date(2024, 3, 1, 0, 0, 1, 0, time.UTC)
+	evening := time.Date(2024, 3, 1, 23, 59, 59, 0, time.UTC)
+	next := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	// 01:00 on March 2nd in UTC+2 is still March 1st in UTC.
+	offset := time.Date(2024, 3, 2, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	prefix := func(key []byte, day time.Time) string {
+		p, err := DailyPrefix(key, day, DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p) != dailyPrefixTokens {
+			t.Fatalf("prefix has %d tokens, want %d", len(p), dailyPrefixTokens)
+		}
+		return string(p)
+	}
+
+	march1 := prefix(key, morning)
+	if prefix(key, evening) != march1 || prefix(key, offset) != march1 {
+		t.Error("prefix changes within a UTC day")
+	}
+	if prefix(key, next) == march1 {
+		t.Error("prefix does not change at midnight UTC")
+	}
+	if prefix([]byte("other key"), morning) == march1 {
+		t.Error("different keys give the same prefix")
+	}
+
+	if _, err := DailyPrefix(key, morning, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
@@ -4,14 +4,21 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
-	"strings"
+	"io"
+	"iter"
+	"maps"
+	"slices"
 	"unicode/utf8"
 )
 
-// EmojiID is a UUID-shaped identifier composed of emoji tokens.
-// Layout: 8-4-4-4-12 emojis (total 32 emojis + 4 dashes).
+// TokenCount is the number of emoji tokens in every EmojiID.
+const TokenCount = 32
+
+// EmojiID is a UUID-shaped identifier composed of TokenCount emoji tokens. Its
+// string form splits them into dash-separated groups following DefaultLayout,
+// which is StandardLayout (8-4-4-4-12) unless changed with SetDefaultLayout.
 type EmojiID struct {
-	tokens [32]rune
+	tokens [TokenCount]rune
 }
 
 // Common errors.
@@ -20,8 +27,40 @@ var (
 	ErrInvalidToken     = errors.New("emojid: invalid token (emoji not in alphabet)")
 	ErrEntropyFailure   = errors.New("emojid: failed to read crypto randomness")
 	ErrAlphabetTooSmall = errors.New("emojid: emoji alphabet must contain at least 2 entries")
+	ErrInvalidLayout    = errors.New("emojid: layout groups must be positive and sum to 32 tokens")
+	ErrSourceExhausted  = errors.New("emojid: static source has no more IDs")
+	ErrInvalidAlphabet  = errors.New("emojid: invalid alphabet")
+	ErrInvalidSeparator = errors.New("emojid: invalid group separator")
+	ErrChecksumMismatch = errors.New("emojid: checksum does not match")
+	ErrIndexOutOfRange  = errors.New("emojid: token index out of range")
+	ErrInvalidVersion   = errors.New("emojid: invalid format version")
+
+	ErrConstraintInfeasible = errors.New("emojid: generation constraint cannot be satisfied")
+	ErrInsufficientEntropy  = errors.New("emojid: alphabet yields too little entropy")
+	ErrOverflow             = errors.New("emojid: value does not fit")
+	ErrInvalidCodepoint     = errors.New("emojid: alphabet entry is not a usable code point")
+	ErrSignatureMismatch    = errors.New("emojid: signature does not match")
+
+	// ErrInvalidUTF8 is returned when the input is not valid UTF-8. It wraps ErrInvalidFormat.
+	ErrInvalidUTF8 = fmt.Errorf("%w: invalid UTF-8", ErrInvalidFormat)
 )
 
+// TokenError reports a token that is not in the alphabet together with its
+// position (0-31) among the tokens. It wraps ErrInvalidToken, so both
+// errors.Is(err, ErrInvalidToken) and errors.As(err, &tokenErr) work.
+type TokenError struct {
+	Index int
+	Token rune
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("%v: %q at index %d", ErrInvalidToken, string(e.Token), e.Index)
+}
+
+func (e *TokenError) Unwrap() error {
+	return ErrInvalidToken
+}
+
 // DefaultAlphabet is a curated set of single-codepoint emoji.
 // Avoids ZWJ sequences, flags, skin tones, and other multi-codepoint grapheme clusters.
 var DefaultAlphabet = []rune{
@@ -46,9 +85,10 @@ var DefaultAlphabet = []rune{
 	'🛡', '⚙', '🧪', '🧬', '🔭', '📡', '💾', '🗄',
 }
 
-// New returns a new random EmojiID using DefaultAlphabet.
+// New returns a new random EmojiID using the default alphabet: DefaultAlphabet
+// unless changed with SetDefaultAlphabet.
 func New() (EmojiID, error) {
-	return NewWithAlphabet(DefaultAlphabet)
+	return NewWithAlphabet(defaultAlphabet())
 }
 
 // MustNew is like New but panics on error.
@@ -82,12 +122,21 @@ func NewWithAlphabet(alphabet []rune) (EmojiID, error) {
 		return EmojiID{}, ErrAlphabetTooSmall
 	}
 
+	id, err := newWithSampler(alphabet, sampler{r: rand.Reader})
+	if err == nil && countGenerated.Load() {
+		generatedCount.Add(1)
+	}
+	return id, err
+}
+
+// newWithSampler fills every token from alphabet using s.
+func newWithSampler(alphabet []rune, s sampler) (EmojiID, error) {
 	var id EmojiID
 
 	// We need 32 independent random choices in [0, len(alphabet)).
-	// Use rejection sampling from crypto/rand to avoid modulo bias.
+	// Use rejection sampling to avoid modulo bias.
 	for i := 0; i < len(id.tokens); i++ {
-		idx, err := cryptoRandIndex(len(alphabet))
+		idx, err := s.index(len(alphabet))
 		if err != nil {
 			return EmojiID{}, err
 		}
@@ -97,29 +146,90 @@ func NewWithAlphabet(alphabet []rune) (EmojiID, error) {
 	return id, nil
 }
 
-// String formats the EmojiID in the UUID-like layout: 8-4-4-4-12 emojis.
+// Nil is the zero EmojiID, the conventional value for "no ID", like uuid.Nil. It
+// never comes out of New. Its String form is the empty string, which Parse
+// rejects; use ParseAllowNil where an absent ID is acceptable.
+var Nil EmojiID
+
+// String formats the EmojiID in the default layout: 8-4-4-4-12 emojis unless
+// changed with SetDefaultLayout. Nil formats as the empty string.
 func (e EmojiID) String() string {
-	// 32 emojis + 4 dashes.
-	var b strings.Builder
-	b.Grow(32*utf8.UTFMax + 4)
+	return e.format(DefaultLayout(), '-')
+}
+
+// FormatWithSeparator formats the EmojiID in the default layout using sep instead
+// of '-' between groups. To parse the result back with ParseWithSeparator, sep must
+// not be a token of the alphabet.
+func (e EmojiID) FormatWithSeparator(sep rune) string {
+	return e.format(DefaultLayout(), sep)
+}
+
+// format writes the tokens split into the groups of l, separated by sep.
+// l must already be valid.
+func (e EmojiID) format(l Layout, sep rune) string {
+	// TokenCount emojis + at most one separator between each pair of tokens.
+	var buf [(2*TokenCount - 1) * utf8.UTFMax]byte
+	return string(e.appendFormat(buf[:0], l, sep))
+}
 
-	writeRunes := func(from, to int) {
-		for i := from; i < to; i++ {
-			b.WriteRune(e.tokens[i])
+// AppendString appends the String form of the EmojiID to dst and returns the
+// extended buffer. Reusing dst avoids the allocation String makes per call.
+func (e EmojiID) AppendString(dst []byte) []byte {
+	return e.appendFormat(dst, DefaultLayout(), '-')
+}
+
+// appendFormat appends the formatted tokens to dst, or nothing for Nil.
+func (e EmojiID) appendFormat(dst []byte, l Layout, sep rune) []byte {
+	if e.IsZero() {
+		return dst
+	}
+
+	for g, span := range l.spans() {
+		if g > 0 {
+			dst = utf8.AppendRune(dst, sep)
+		}
+		for _, r := range e.tokens[span[0]:span[1]] {
+			dst = utf8.AppendRune(dst, r)
 		}
 	}
+	return dst
+}
 
-	writeRunes(0, 8)
-	b.WriteByte('-')
-	writeRunes(8, 12)
-	b.WriteByte('-')
-	writeRunes(12, 16)
-	b.WriteByte('-')
-	writeRunes(16, 20)
-	b.WriteByte('-')
-	writeRunes(20, 32)
+// Groups returns an iterator over the groups of the default layout, yielding each
+// group's index and its tokens, for custom formatting:
+//
+//	for g, group := range id.Groups() {
+//		if g > 0 {
+//			b.WriteByte('-')
+//		}
+//		b.WriteString(string(group))
+//	}
+//
+// The slices belong to a copy of the ID, so modifying them does not change it.
+func (e EmojiID) Groups() iter.Seq2[int, []rune] {
+	return func(yield func(int, []rune) bool) {
+		for g, span := range DefaultLayout().spans() {
+			if !yield(g, e.tokens[span[0]:span[1]:span[1]]) {
+				return
+			}
+		}
+	}
+}
 
-	return b.String()
+// spans returns an iterator over the groups of l, yielding each group's index and
+// the range [start, end) of token positions it covers. l must already be valid.
+// Unlike Groups it hands out no slices of the ID, so formatting through it does
+// not move the ID to the heap.
+func (l Layout) spans() iter.Seq2[int, [2]int] {
+	return func(yield func(int, [2]int) bool) {
+		i := 0
+		for g, n := range l {
+			if !yield(g, [2]int{i, i + n}) {
+				return
+			}
+			i += n
+		}
+	}
 }
 
 // Equal compares two EmojiIDs.
@@ -127,15 +237,31 @@ func (e EmojiID) Equal(other EmojiID) bool {
 	return e.tokens == other.tokens
 }
 
-// IsZero reports whether this is the zero value (all tokens are 0 runes).
+// Compare returns -1, 0 or +1 depending on whether e sorts before, equal to or
+// after other, comparing tokens by code point from left to right.
+func (e EmojiID) Compare(other EmojiID) int {
+	return slices.Compare(e.tokens[:], other.tokens[:])
+}
+
+// IsZero reports whether this is the zero value Nil (all tokens are 0 runes).
 func (e EmojiID) IsZero() bool {
 	var z EmojiID
 	return e.tokens == z.tokens
 }
 
-// Parse parses an EmojiID string in 8-4-4-4-12 emoji layout using DefaultAlphabet.
+// Zero overwrites the tokens with zero runes so that IsZero reports true.
+//
+// This is best-effort hygiene for IDs used as secrets: it only wipes this copy.
+// EmojiID is a value type, so every earlier copy (function arguments, strings from
+// String, the garbage collector moving memory) may still hold the tokens.
+func (e *EmojiID) Zero() {
+	clear(e.tokens[:])
+}
+
+// Parse parses an EmojiID string in the default layout using the default
+// alphabet: DefaultAlphabet unless changed with SetDefaultAlphabet.
 func Parse(s string) (EmojiID, error) {
-	return ParseWithAlphabet(s, DefaultAlphabet)
+	return ParseWithAlphabet(s, defaultAlphabet())
 }
 
 // MustParse panics if Parse fails.
@@ -147,57 +273,269 @@ func MustParse(s string) EmojiID {
 	return id
 }
 
-// ParseWithAlphabet parses an EmojiID string in 8-4-4-4-12 layout and validates
+// ParseWithAlphabet parses an EmojiID string in the default layout and validates
 // that every emoji token is present in the given alphabet.
 func ParseWithAlphabet(s string, alphabet []rune) (EmojiID, error) {
 	if len(alphabet) < 2 {
 		return EmojiID{}, ErrAlphabetTooSmall
 	}
 
-	parts := strings.Split(s, "-")
-	if len(parts) != 5 {
-		return EmojiID{}, ErrInvalidFormat
+	allowed := cachedAlphabetSet(alphabet)
+	return parseLayout(s, DefaultLayout(), func(int) map[rune]struct{} { return allowed })
+}
+
+// ParseAllowNil is like ParseWithAlphabet but returns Nil for the empty string,
+// the String form of Nil, instead of an error.
+func ParseAllowNil(s string, alphabet []rune) (EmojiID, error) {
+	if s == "" {
+		return Nil, nil
 	}
+	return ParseWithAlphabet(s, alphabet)
+}
 
-	// Expect emoji counts: 8,4,4,4,12
-	want := []int{8, 4, 4, 4, 12}
-	total := 0
-	for _, w := range want {
-		total += w
+// ParseWithSeparator parses an EmojiID formatted by FormatWithSeparator, with sep
+// between the groups of the default layout. It returns ErrInvalidSeparator if sep is itself
+// a token of alphabet or not a valid code point.
+func ParseWithSeparator(s string, sep rune, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
 	}
 
-	var tokens []rune
-	tokens = make([]rune, 0, total)
+	allowed := cachedAlphabetSet(alphabet)
+	if _, ok := allowed[sep]; ok || !utf8.ValidRune(sep) {
+		return EmojiID{}, fmt.Errorf("%w: %q", ErrInvalidSeparator, sep)
+	}
 
-	for i, p := range parts {
-		r := []rune(p)
-		if len(r) != want[i] {
-			return EmojiID{}, ErrInvalidFormat
+	isSep := func(r rune) bool { return r == sep }
+	return parseGroups(s, DefaultLayout(), isSep, func(int) map[rune]struct{} { return allowed })
+}
+
+// ParseReaderN parses the canonical EmojiID at the start of b and returns it with
+// the number of bytes it occupies, so a wire protocol can advance past it and keep
+// the rest of the buffer. The leading bytes must be exactly one ID in the default layout
+// whose tokens are all in alphabet; whatever follows is not inspected.
+func ParseReaderN(b []byte, alphabet []rune) (EmojiID, int, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, 0, ErrAlphabetTooSmall
+	}
+
+	allowed := cachedAlphabetSet(alphabet)
+	next := func() (rune, error) {
+		if len(b) == 0 {
+			return 0, ErrInvalidFormat
+		}
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size <= 1 {
+			return 0, ErrInvalidUTF8
 		}
-		tokens = append(tokens, r...)
+		b = b[size:]
+		return r, nil
 	}
 
-	if len(tokens) != 32 {
-		return EmojiID{}, ErrInvalidFormat
+	var id EmojiID
+	n, i := len(b), 0
+	for g, span := range DefaultLayout().spans() {
+		if g > 0 {
+			r, err := next()
+			if err != nil {
+				return EmojiID{}, 0, err
+			}
+			if r != '-' {
+				return EmojiID{}, 0, ErrInvalidFormat
+			}
+		}
+		for range span[1] - span[0] {
+			r, err := next()
+			if err != nil {
+				return EmojiID{}, 0, err
+			}
+			if _, ok := allowed[r]; !ok {
+				if r == '-' {
+					return EmojiID{}, 0, ErrInvalidFormat
+				}
+				return EmojiID{}, 0, &TokenError{Index: i, Token: r}
+			}
+			id.tokens[i] = r
+			i++
+		}
 	}
 
-	allowed := make(map[rune]struct{}, len(alphabet))
-	for _, r := range alphabet {
-		allowed[r] = struct{}{}
+	return id, n - len(b), nil
+}
+
+// ParseFlexible is like ParseWithAlphabet but accepts '-', ' ', U+00A0 (no-break
+// space) and '_' interchangeably between groups, as emitted by various producers.
+// Exactly one separator must sit between each pair of groups, and the groups must
+// still have the sizes of the default layout.
+func ParseFlexible(s string, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	allowed := cachedAlphabetSet(alphabet)
+	return parseGroups(s, DefaultLayout(), isFlexibleSeparator, func(int) map[rune]struct{} { return allowed })
+}
+
+func isFlexibleSeparator(r rune) bool {
+	switch r {
+	case '-', ' ', '\u00A0', '_':
+		return true
+	}
+	return false
+}
+
+// wrappers maps each opening quote or bracket accepted by ParseUnwrapped to its
+// closing counterpart.
+var wrappers = map[rune]rune{
+	'"': '"', '\'': '\'', '`': '`',
+	'[': ']', '(': ')', '{': '}', '<': '>',
+	'“': '”', '‘': '’', '«': '»',
+}
+
+// ParseUnwrapped is like ParseWithAlphabet but first strips one pair of matching
+// quotes, backticks or brackets around s, as in IDs copied from JSON or log lines.
+// The pair is only stripped when both ends match, so input such as "[😀…" is left
+// as is and fails to parse.
+func ParseUnwrapped(s string, alphabet []rune) (EmojiID, error) {
+	open, n := utf8.DecodeRuneInString(s)
+	if closing, ok := wrappers[open]; ok && len(s) >= 2*n {
+		if last, m := utf8.DecodeLastRuneInString(s[n:]); last == closing {
+			s = s[n : len(s)-m]
+		}
 	}
+	return ParseWithAlphabet(s, alphabet)
+}
+
+// RepairSeparators salvages a typed ID whose tokens are right but whose dashes are
+// missing, doubled or misplaced: it ignores every '-', requires exactly 32 tokens
+// from alphabet, and regroups them in the default layout. The bool reports
+// whether s differed from the canonical form, so callers can tell users their
+// input was corrected.
+func RepairSeparators(s string, alphabet []rune) (EmojiID, bool, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, false, ErrAlphabetTooSmall
+	}
+	if !utf8.ValidString(s) {
+		return EmojiID{}, false, ErrInvalidUTF8
+	}
+	allowed := cachedAlphabetSet(alphabet)
 
 	var id EmojiID
-	for i := 0; i < 32; i++ {
-		if _, ok := allowed[tokens[i]]; !ok {
-			return EmojiID{}, fmt.Errorf("%w: %q", ErrInvalidToken, string(tokens[i]))
+	n := 0
+	for _, r := range s {
+		if r == '-' {
+			continue
+		}
+		if n == TokenCount {
+			return EmojiID{}, false, fmt.Errorf("%w: more than %d tokens", ErrInvalidFormat, TokenCount)
+		}
+		if _, ok := allowed[r]; !ok {
+			return EmojiID{}, false, &TokenError{Index: n, Token: r}
+		}
+		id.tokens[n] = r
+		n++
+	}
+	if n != TokenCount {
+		return EmojiID{}, false, fmt.Errorf("%w: %d tokens, want %d", ErrInvalidFormat, n, TokenCount)
+	}
+	return id, s != id.String(), nil
+}
+
+// ParseWithAlphabets parses s against each of the named alphabets and returns the
+// ID together with the name of the alphabet that validated it. Names are tried in
+// sorted order, so the result is deterministic when alphabets overlap. If no
+// alphabet accepts every token, the error wraps ErrInvalidToken. Every alphabet
+// is checked for size before any is tried, so an unusable entry is reported
+// whatever s is.
+func ParseWithAlphabets(s string, alphabets map[string][]rune) (EmojiID, string, error) {
+	names := slices.Sorted(maps.Keys(alphabets))
+	for _, name := range names {
+		if len(alphabets[name]) < 2 {
+			return EmojiID{}, "", fmt.Errorf("alphabet %q: %w", name, ErrAlphabetTooSmall)
+		}
+	}
+
+	for _, name := range names {
+		id, err := ParseWithAlphabet(s, alphabets[name])
+		if err == nil {
+			return id, name, nil
+		}
+		if !errors.Is(err, ErrInvalidToken) {
+			return EmojiID{}, "", fmt.Errorf("alphabet %q: %w", name, err)
+		}
+	}
+	return EmojiID{}, "", fmt.Errorf("%w: no alphabet matched", ErrInvalidToken)
+}
+
+// parseLayout splits s on dashes, checks the group sizes against l and checks
+// every token of group g against allowed(g).
+func parseLayout(s string, l Layout, allowed func(group int) map[rune]struct{}) (EmojiID, error) {
+	return parseGroups(s, l, isDash, allowed)
+}
+
+func isDash(r rune) bool { return r == '-' }
+
+// parseGroups is parseLayout with a caller-chosen set of separators.
+func parseGroups(s string, l Layout, isSep func(rune) bool, allowed func(group int) map[rune]struct{}) (EmojiID, error) {
+	// Invalid bytes would otherwise decode to U+FFFD and fail the alphabet check
+	// with a confusing message.
+	if !utf8.ValidString(s) {
+		return EmojiID{}, ErrInvalidUTF8
+	}
+
+	parts := splitGroups(s, isSep)
+	if len(parts) != len(l) {
+		return EmojiID{}, ErrInvalidFormat
+	}
+
+	var id EmojiID
+	i := 0
+	for g, p := range parts {
+		r := []rune(p)
+		if len(r) != l[g] {
+			return EmojiID{}, ErrInvalidFormat
+		}
+
+		set := allowed(g)
+		for _, t := range r {
+			if _, ok := set[t]; !ok {
+				return EmojiID{}, &TokenError{Index: i, Token: t}
+			}
+			id.tokens[i] = t
+			i++
 		}
-		id.tokens[i] = tokens[i]
 	}
 
 	return id, nil
 }
 
-// Validate reports whether s is a valid EmojiID formatted string using DefaultAlphabet.
+// splitGroups splits s at every rune for which isSep reports true. Unlike
+// strings.FieldsFunc it keeps empty groups, so doubled separators are rejected.
+func splitGroups(s string, isSep func(rune) bool) []string {
+	var parts []string
+	start := 0
+	for i, r := range s {
+		if isSep(r) {
+			parts = append(parts, s[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// alphabetSet returns the alphabet as a set for membership checks. It builds a
+// new map on every call; alphabets should go through cachedAlphabetSet, which
+// leaves this for one-off sets such as the tokens of a single ID.
+func alphabetSet(alphabet []rune) map[rune]struct{} {
+	allowed := make(map[rune]struct{}, len(alphabet))
+	for _, r := range alphabet {
+		allowed[r] = struct{}{}
+	}
+	return allowed
+}
+
+// Validate reports whether s is a valid EmojiID formatted string using the default
+// alphabet.
 func Validate(s string) bool {
 	_, err := Parse(s)
 	return err == nil
@@ -210,26 +548,92 @@ func (e EmojiID) Tokens() []rune {
 	return out
 }
 
+// TokensInto copies the tokens into dst and returns the number copied, which is
+// less than 32 only if dst is shorter. Unlike Tokens it does not allocate, so a
+// reused buffer suits hot rendering paths.
+func (e EmojiID) TokensInto(dst []rune) int {
+	return copy(dst, e.tokens[:])
+}
+
+// TokenAt returns the token at position i (0-31) without copying all of them.
+func (e EmojiID) TokenAt(i int) (rune, error) {
+	if i < 0 || i >= TokenCount {
+		return 0, fmt.Errorf("%w: %d", ErrIndexOutOfRange, i)
+	}
+	return e.tokens[i], nil
+}
+
+// SetTokenAt replaces the token at position i (0-31) with r. It does not check r
+// against an alphabet, only that it is a valid code point; the caller must ensure
+// the result still parses with the intended alphabet.
+func (e *EmojiID) SetTokenAt(i int, r rune) error {
+	if i < 0 || i >= TokenCount {
+		return fmt.Errorf("%w: %d", ErrIndexOutOfRange, i)
+	}
+	if r == 0 || !utf8.ValidRune(r) {
+		return fmt.Errorf("%w: %U", ErrInvalidCodepoint, r)
+	}
+	e.tokens[i] = r
+	return nil
+}
+
+// Rotate returns a copy of the EmojiID with its tokens cyclically shifted n
+// positions to the right (negative n shifts left). Rotate(-n) undoes Rotate(n).
+// It only reorders the display and is not a security measure.
+func (e EmojiID) Rotate(n int) EmojiID {
+	n %= TokenCount
+	if n < 0 {
+		n += TokenCount
+	}
+
+	var out EmojiID
+	for i, r := range e.tokens {
+		out.tokens[(i+n)%TokenCount] = r
+	}
+	return out
+}
+
 // --- internal randomness helpers ---
 
+// sampler draws unbiased indices from r by rejection sampling, optionally
+// recording its draws and rejections.
+type sampler struct {
+	r     io.Reader
+	stats *SamplingStats
+}
+
 func cryptoRandIndex(n int) (int, error) {
+	return sampler{r: rand.Reader}.index(n)
+}
+
+func (s sampler) index(n int) (int, error) {
 	if n <= 0 {
 		return 0, ErrAlphabetTooSmall
 	}
+	if n > MaxAlphabetSize {
+		// The rejection limit below would be 0 and the loop would never end.
+		return 0, fmt.Errorf("%w: %d entries exceed the limit of %d", ErrInvalidAlphabet, n, MaxAlphabetSize)
+	}
 
 	// Rejection sampling using a random byte stream.
 	// We’ll draw uint16 values to comfortably cover alphabets up to 65535.
 	var buf [2]byte
-	max := uint32(1<<16) // 65536
+	max := uint32(1 << 16) // 65536
 	limit := max - (max % uint32(n))
 
 	for {
-		if _, err := rand.Read(buf[:]); err != nil {
+		if _, err := io.ReadFull(s.r, buf[:]); err != nil {
 			return 0, ErrEntropyFailure
 		}
 		v := uint32(buf[0])<<8 | uint32(buf[1]) // 0..65535
+		if s.stats != nil {
+			s.stats.draws.Add(1)
+		}
 		if v < limit {
 			return int(v % uint32(n)), nil
 		}
+		if s.stats != nil {
+			s.stats.rejections.Add(1)
+		}
 	}
 }
@@ -0,0 +1,449 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	for range 100 {
+		id := MustNew()
+		got, err := Parse(id.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != id {
+			t.Fatalf("Parse(%q) = %v", id.String(), got)
+		}
+	}
+}
+
+func TestParseInvalidUTF8(t *testing.T) {
+	s := MustNewString()
+	for _, in := range []string{
+		"\xff" + s[1:],
+		s[:len(s)-1],
+		s[:10] + "\xc0\x80" + s[10:],
+		"\xed\xa0\x80", // encoded surrogate
+	} {
+		_, err := Parse(in)
+		if !errors.Is(err, ErrInvalidUTF8) {
+			t.Errorf("Parse(%q): got %v, want ErrInvalidUTF8", in, err)
+		}
+		if !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Parse(%q): %v does not wrap ErrInvalidFormat", in, err)
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	id := MustNew()
+	for _, n := range []int{0, 1, 5, 31, 32, 33, -1, -40, 1000} {
+		r := id.Rotate(n)
+		if back := r.Rotate(-n); back != id {
+			t.Errorf("Rotate(%d).Rotate(%d) = %v, want %v", n, -n, back, id)
+		}
+
+		want := make(map[rune]int)
+		for _, tok := range id.Tokens() {
+			want[tok]++
+		}
+		for _, tok := range r.Tokens() {
+			want[tok]--
+		}
+		for tok, c := range want {
+			if c != 0 {
+				t.Errorf("Rotate(%d) changed the count of %q by %d", n, string(tok), -c)
+			}
+		}
+	}
+
+	if got, want := id.Rotate(1).Tokens()[0], id.Tokens()[TokenCount-1]; got != want {
+		t.Errorf("Rotate(1) first token = %q, want %q", string(got), string(want))
+	}
+}
+
+func TestParseWithAlphabets(t *testing.T) {
+	id, err := NewWithAlphabet(testAnimals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alphabets := map[string][]rune{"faces": testFaces, "animals": testAnimals}
+
+	got, name, err := ParseWithAlphabets(id.String(), alphabets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id || name != "animals" {
+		t.Errorf("got %v from %q, want %v from \"animals\"", got, name, id)
+	}
+
+	_, _, err = ParseWithAlphabets(MustNewString(), alphabets)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("no match: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestParseWithAlphabetsInvalidAlphabet(t *testing.T) {
+	alphabets := map[string][]rune{"a": {'x'}, "b": DefaultAlphabet}
+	for range 10 {
+		_, _, err := ParseWithAlphabets(MustNewString(), alphabets)
+		if !errors.Is(err, ErrAlphabetTooSmall) {
+			t.Fatalf("got %v, want ErrAlphabetTooSmall", err)
+		}
+	}
+}
+
+func TestZero(t *testing.T) {
+	id := MustNew()
+	if id.IsZero() {
+		t.Fatal("new ID is zero")
+	}
+	id.Zero()
+	if !id.IsZero() {
+		t.Error("IsZero() is false after Zero()")
+	}
+	if id != Nil {
+		t.Error("zeroed ID differs from Nil")
+	}
+}
+
+func TestParseFlexible(t *testing.T) {
+	id := MustNew()
+	for _, sep := range []rune{'-', ' ', '\u00A0', '_'} {
+		s := id.FormatWithSeparator(sep)
+		got, err := ParseFlexible(s, DefaultAlphabet)
+		if err != nil {
+			t.Errorf("separator %U: %v", sep, err)
+			continue
+		}
+		if got != id {
+			t.Errorf("separator %U: got %v, want %v", sep, got, id)
+		}
+	}
+
+	// Mixed separators are fine too.
+	r := []rune(id.String())
+	r[8], r[13] = ' ', '_'
+	if got, err := ParseFlexible(string(r), DefaultAlphabet); err != nil || got != id {
+		t.Errorf("mixed separators: got %v, %v", got, err)
+	}
+}
+
+func TestParseFlexibleErrors(t *testing.T) {
+	s := MustNewString()
+	for _, in := range []string{
+		strings.Replace(s, "-", "--", 1),
+		strings.Replace(s, "-", "", 1),
+		strings.Replace(s, "-", "+", 1),
+		strings.Replace(s, "-", "\t", 1),
+	} {
+		if _, err := ParseFlexible(in, DefaultAlphabet); err == nil {
+			t.Errorf("ParseFlexible(%q): no error", in)
+		}
+	}
+}
+
+func TestSeparatorRoundTrip(t *testing.T) {
+	id := MustNew()
+	s := id.FormatWithSeparator('_')
+	if strings.ContainsRune(s, '-') || strings.Count(s, "_") != len(DefaultLayout())-1 {
+		t.Fatalf("FormatWithSeparator('_') = %q", s)
+	}
+	got, err := ParseWithSeparator(s, '_', DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("round trip = %v, want %v", got, id)
+	}
+
+	if _, err := ParseWithSeparator(id.String(), '_', DefaultAlphabet); err == nil {
+		t.Error("dash-separated input accepted with '_' separator")
+	}
+	if _, err := ParseWithSeparator(s, '😀', DefaultAlphabet); !errors.Is(err, ErrInvalidSeparator) {
+		t.Errorf("alphabet token as separator: got %v, want ErrInvalidSeparator", err)
+	}
+}
+
+func TestFlagAlphabetCountsRunes(t *testing.T) {
+	// Regional indicators are single code points, but adjacent pairs render as
+	// one flag, so the visible grapheme count differs from the token count.
+	// Tokens are still counted per rune.
+	alphabet := make([]rune, 26)
+	for i := range alphabet {
+		alphabet[i] = 0x1F1E6 + rune(i)
+	}
+	if err := ValidateAlphabet(alphabet); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := FromIndices(make([]int, TokenCount), alphabet) // 🇦 x 32
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := id.String()
+	got, err := ParseWithAlphabet(s, alphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("round trip = %v, want %v", got, id)
+	}
+
+	// 🇺🇸 is two tokens, so a group holding it in place of one token is too long.
+	r := []rune(s)
+	bad := string(r[:7]) + "🇺🇸" + string(r[8:])
+	if _, err := ParseWithAlphabet(bad, alphabet); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("flag in place of one token: got %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestParseReaderN(t *testing.T) {
+	id := MustNew()
+	s := id.String()
+	for _, rest := range []string{"", " trailing", "-" + s, "\xff"} {
+		b := []byte(s + rest)
+		got, n, err := ParseReaderN(b, DefaultAlphabet)
+		if err != nil {
+			t.Fatalf("rest %q: %v", rest, err)
+		}
+		if got != id || n != len(s) {
+			t.Errorf("rest %q: got %v, %d; want %v, %d", rest, got, n, id, len(s))
+		}
+		if string(b[n:]) != rest {
+			t.Errorf("remaining bytes = %q, want %q", b[n:], rest)
+		}
+	}
+}
+
+func TestParseReaderNErrors(t *testing.T) {
+	s := MustNew().String()
+	var tokenErr *TokenError
+	for _, tc := range []struct {
+		in   string
+		want error
+	}{
+		{"", ErrInvalidFormat},
+		{s[:len(s)-4], ErrInvalidFormat},
+		{strings.Replace(s, "-", "", 1), ErrInvalidFormat},
+		{strings.Replace(s, "-", "_", 1), ErrInvalidFormat},
+		{"\xff" + s, ErrInvalidUTF8},
+		{"🕐" + s, ErrInvalidToken},
+	} {
+		if _, _, err := ParseReaderN([]byte(tc.in), DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("ParseReaderN(%q): got %v, want %v", tc.in, err, tc.want)
+		}
+	}
+	if _, _, err := ParseReaderN([]byte("🕐"+s), DefaultAlphabet); !errors.As(err, &tokenErr) || tokenErr.Index != 0 {
+		t.Errorf("got %v, want *TokenError at index 0", err)
+	}
+}
+
+func TestTokenError(t *testing.T) {
+	id := MustNew()
+	r := []rune(id.String())
+	r[10] = '🕐' // token 9: eight tokens, a dash, then the second token of group 2
+
+	_, err := Parse(string(r))
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) {
+		t.Fatalf("got %v, want *TokenError", err)
+	}
+	if tokenErr.Index != 9 || tokenErr.Token != '🕐' {
+		t.Errorf("TokenError = %+v, want index 9 and token 🕐", *tokenErr)
+	}
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Error("TokenError does not wrap ErrInvalidToken")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "index 9") || !strings.Contains(msg, "🕐") {
+		t.Errorf("Error() = %q, want the token and index", msg)
+	}
+
+	_, err = id.Indices(testFaces)
+	if !errors.As(err, &tokenErr) || tokenErr.Index != 0 || tokenErr.Token != id.Tokens()[0] {
+		t.Errorf("Indices: got %v, want *TokenError for the first token", err)
+	}
+}
+
+func TestParseUnwrapped(t *testing.T) {
+	id := MustNew()
+	s := id.String()
+	for open, closing := range wrappers {
+		in := string(open) + s + string(closing)
+		got, err := ParseUnwrapped(in, DefaultAlphabet)
+		if err != nil {
+			t.Errorf("ParseUnwrapped(%q): %v", in, err)
+		} else if got != id {
+			t.Errorf("ParseUnwrapped(%q) = %v, want %v", in, got, id)
+		}
+	}
+	if got, err := ParseUnwrapped(s, DefaultAlphabet); err != nil || got != id {
+		t.Errorf("unwrapped input: got %v, %v", got, err)
+	}
+
+	for _, in := range []string{
+		"[" + s + ")",
+		"[" + s,
+		s + "]",
+		`""` + s + `""`,
+		"[",
+		"",
+	} {
+		if _, err := ParseUnwrapped(in, DefaultAlphabet); err == nil {
+			t.Errorf("ParseUnwrapped(%q) succeeded", in)
+		}
+	}
+}
+
+func TestNil(t *testing.T) {
+	if !Nil.IsZero() || Nil.String() != "" {
+		t.Errorf("Nil = %q, IsZero %v", Nil.String(), Nil.IsZero())
+	}
+	if _, err := Parse(""); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Parse(\"\"): got %v, want ErrInvalidFormat", err)
+	}
+
+	id, err := ParseAllowNil("", DefaultAlphabet)
+	if err != nil || id != Nil {
+		t.Errorf("ParseAllowNil(\"\") = %v, %v; want Nil", id, err)
+	}
+	want := MustNew()
+	if id, err := ParseAllowNil(want.String(), DefaultAlphabet); err != nil || id != want {
+		t.Errorf("ParseAllowNil(%q) = %v, %v", want.String(), id, err)
+	}
+	if _, err := ParseAllowNil("-", DefaultAlphabet); err == nil {
+		t.Error("ParseAllowNil(\"-\") succeeded")
+	}
+}
+
+func TestTokenAt(t *testing.T) {
+	id := MustNew()
+	for i, want := range id.Tokens() {
+		if got, err := id.TokenAt(i); err != nil || got != want {
+			t.Errorf("TokenAt(%d) = %q, %v; want %q", i, string(got), err, string(want))
+		}
+	}
+
+	if err := id.SetTokenAt(TokenCount-1, '🐶'); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := id.TokenAt(TokenCount - 1); got != '🐶' {
+		t.Errorf("after SetTokenAt: %q, want 🐶", string(got))
+	}
+	if _, err := Parse(id.String()); err != nil {
+		t.Errorf("modified ID does not parse: %v", err)
+	}
+}
+
+func TestTokenAtErrors(t *testing.T) {
+	id := MustNew()
+	before := id
+	for _, i := range []int{-1, TokenCount} {
+		if _, err := id.TokenAt(i); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("TokenAt(%d): got %v, want ErrIndexOutOfRange", i, err)
+		}
+		if err := id.SetTokenAt(i, '🐶'); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("SetTokenAt(%d): got %v, want ErrIndexOutOfRange", i, err)
+		}
+	}
+	for _, r := range []rune{0, 0xD800, 0x110000} {
+		if err := id.SetTokenAt(0, r); !errors.Is(err, ErrInvalidCodepoint) {
+			t.Errorf("SetTokenAt(0, %U): got %v, want ErrInvalidCodepoint", r, err)
+		}
+	}
+	if id != before {
+		t.Error("failed SetTokenAt modified the ID")
+	}
+}
+
+func TestTokensInto(t *testing.T) {
+	id := MustNew()
+	buf := make([]rune, TokenCount+4)
+	if n := id.TokensInto(buf); n != TokenCount || string(buf[:n]) != string(id.Tokens()) {
+		t.Errorf("TokensInto = %d, %q", n, string(buf[:n]))
+	}
+	short := make([]rune, 5)
+	if n := id.TokensInto(short); n != 5 || string(short) != string(id.Tokens()[:5]) {
+		t.Errorf("short buffer: %d, %q", n, string(short))
+	}
+	if n := id.TokensInto(nil); n != 0 {
+		t.Errorf("nil buffer: %d", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { id.TokensInto(buf) }); n != 0 {
+		t.Errorf("TokensInto allocates %v times, want 0", n)
+	}
+}
+
+// Sinks keep the compiler from optimizing away benchmarked results.
+var (
+	sinkInt   int
+	sinkRunes []rune
+)
+
+func BenchmarkTokensInto(b *testing.B) {
+	id := MustNew()
+	buf := make([]rune, TokenCount)
+	b.ReportAllocs()
+	for b.Loop() {
+		sinkInt = id.TokensInto(buf)
+	}
+	sinkRunes = buf
+}
+
+func BenchmarkTokens(b *testing.B) {
+	id := MustNew()
+	b.ReportAllocs()
+	for b.Loop() {
+		sinkRunes = id.Tokens()
+	}
+}
+
+func TestRepairSeparators(t *testing.T) {
+	id := MustNew()
+	s := id.String()
+	bare := strings.ReplaceAll(s, "-", "")
+	r := []rune(bare)
+	misplaced := string(r[:3]) + "-" + string(r[3:20]) + "--" + string(r[20:])
+
+	for _, tc := range []struct {
+		in       string
+		repaired bool
+	}{
+		{s, false},
+		{bare, true},
+		{misplaced, true},
+		{"-" + s + "-", true},
+	} {
+		got, repaired, err := RepairSeparators(tc.in, DefaultAlphabet)
+		if err != nil {
+			t.Errorf("RepairSeparators(%q): %v", tc.in, err)
+			continue
+		}
+		if got != id || repaired != tc.repaired {
+			t.Errorf("RepairSeparators(%q) = %v, %v; want %v, %v", tc.in, got, repaired, id, tc.repaired)
+		}
+	}
+}
+
+func TestRepairSeparatorsErrors(t *testing.T) {
+	bare := strings.ReplaceAll(MustNew().String(), "-", "")
+	var tokenErr *TokenError
+	for _, tc := range []struct {
+		in   string
+		want error
+	}{
+		{bare + "😀", ErrInvalidFormat},
+		{string([]rune(bare)[1:]), ErrInvalidFormat},
+		{bare[:len(bare)-1], ErrInvalidUTF8},
+		{"🕐" + string([]rune(bare)[1:]), ErrInvalidToken},
+	} {
+		if _, _, err := RepairSeparators(tc.in, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("RepairSeparators(%q): got %v, want %v", tc.in, err, tc.want)
+		}
+	}
+	if _, _, err := RepairSeparators("😀-😀 "+bare, DefaultAlphabet); !errors.As(err, &tokenErr) || tokenErr.Index != 2 {
+		t.Errorf("space: got %v, want *TokenError at index 2", err)
+	}
+}
@@ -0,0 +1,437 @@
+package emojid
+
+import (
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// EntropyBits returns the entropy in bits of an EmojiID drawn uniformly from an
+// alphabet of the given size: TokenCount * log2(alphabetSize).
+func EntropyBits(alphabetSize int) float64 {
+	if alphabetSize < 2 {
+		return 0
+	}
+	return TokenCount * math.Log2(float64(alphabetSize))
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical string form,
+// which is empty for Nil.
+func (e EmojiID) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using Parse. Empty text
+// decodes to Nil, so every value round-trips through MarshalText.
+func (e *EmojiID) UnmarshalText(text []byte) error {
+	id, err := ParseAllowNil(string(text), defaultAlphabet())
+	if err != nil {
+		return err
+	}
+	*e = id
+	return nil
+}
+
+// Set implements flag.Value using Parse, so an EmojiID can be a command-line flag:
+//
+//	var id emojid.EmojiID
+//	flag.Var(&id, "id", "resource ID")
+func (e *EmojiID) Set(s string) error {
+	id, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*e = id
+	return nil
+}
+
+// EmojiIDValue adapts an EmojiID to the spf13/pflag Value interface used by cobra,
+// which adds Type to flag.Value. It only needs the method set, so this package
+// does not import pflag:
+//
+//	var id emojid.EmojiID
+//	cmd.Flags().Var(emojid.NewEmojiIDValue(&id), "id", "resource ID")
+type EmojiIDValue struct {
+	p *EmojiID
+}
+
+// NewEmojiIDValue returns an EmojiIDValue that stores parsed flags in p.
+func NewEmojiIDValue(p *EmojiID) *EmojiIDValue {
+	return &EmojiIDValue{p: p}
+}
+
+// Set parses s with Parse into the target EmojiID.
+func (v *EmojiIDValue) Set(s string) error {
+	return v.p.Set(s)
+}
+
+// String returns the canonical form of the target EmojiID.
+func (v *EmojiIDValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return v.p.String()
+}
+
+// Type returns "emojid", the type name shown in pflag usage messages.
+func (v *EmojiIDValue) Type() string {
+	return "emojid"
+}
+
+// MarshalJSON encodes the EmojiID as a JSON string in canonical form; Nil encodes
+// as "".
+func (e EmojiID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON decodes a JSON string like UnmarshalText, so "" decodes to Nil.
+func (e *EmojiID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return e.UnmarshalText([]byte(s))
+}
+
+// LogValue implements slog.LogValuer, so IDs log as their canonical string. Nil
+// logs as "nil" rather than an empty value.
+func (e EmojiID) LogValue() slog.Value {
+	if e.IsZero() {
+		return slog.StringValue("nil")
+	}
+	return slog.StringValue(e.String())
+}
+
+// ProtoString returns the value to put in a protobuf string field. Protobuf has
+// no way to carry EmojiID as a type, so the canonical string is the transport;
+// ProtoString is String under an explicit name so mappers read symmetrically with
+// FromProtoString.
+func (e EmojiID) ProtoString() string {
+	return e.String()
+}
+
+// FromProtoString parses a protobuf string field written with ProtoString, using
+// the default alphabet. The empty string, which is also what proto3 reports for
+// an unset field, decodes to Nil.
+func FromProtoString(s string) (EmojiID, error) {
+	return ParseAllowNil(s, defaultAlphabet())
+}
+
+// verboseJSON is the audit-log encoding produced by MarshalJSONVerbose.
+type verboseJSON struct {
+	ID           string  `json:"id"`
+	AlphabetSize int     `json:"alphabet_size"`
+	EntropyBits  float64 `json:"entropy_bits"`
+}
+
+// MarshalJSONVerbose is like MarshalJSONVerboseWithAlphabet using the default
+// alphabet.
+func (e EmojiID) MarshalJSONVerbose() ([]byte, error) {
+	return e.MarshalJSONVerboseWithAlphabet(defaultAlphabet())
+}
+
+// MarshalJSONVerboseWithAlphabet encodes the EmojiID together with the alphabet it
+// was drawn from, for audit logs:
+//
+//	{"id":"...","alphabet_size":N,"entropy_bits":B}
+//
+// Every token must be present in alphabet, except for Nil, whose id is "" as in
+// MarshalJSON. MarshalJSON is unaffected and keeps encoding the plain string.
+func (e EmojiID) MarshalJSONVerboseWithAlphabet(alphabet []rune) ([]byte, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+
+	if !e.IsZero() {
+		allowed := cachedAlphabetSet(alphabet)
+		for i, r := range e.tokens {
+			if _, ok := allowed[r]; !ok {
+				return nil, &TokenError{Index: i, Token: r}
+			}
+		}
+	}
+
+	return json.Marshal(verboseJSON{
+		ID:           e.String(),
+		AlphabetSize: len(alphabet),
+		EntropyBits:  EntropyBits(len(alphabet)),
+	})
+}
+
+// Indices returns the position in alphabet of each of the TokenCount tokens.
+func (e EmojiID) Indices(alphabet []rune) ([]int, error) {
+	index := alphabetIndex(alphabet)
+
+	out := make([]int, TokenCount)
+	for i, r := range e.tokens {
+		idx, ok := index[r]
+		if !ok {
+			return nil, &TokenError{Index: i, Token: r}
+		}
+		out[i] = idx
+	}
+	return out, nil
+}
+
+// FromIndices builds an EmojiID from TokenCount positions in alphabet.
+func FromIndices(indices []int, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+	if len(indices) != TokenCount {
+		return EmojiID{}, ErrInvalidFormat
+	}
+
+	var id EmojiID
+	for i, idx := range indices {
+		if idx < 0 || idx >= len(alphabet) {
+			return EmojiID{}, fmt.Errorf("%w: index %d out of range", ErrInvalidToken, idx)
+		}
+		id.tokens[i] = alphabet[idx]
+	}
+	return id, nil
+}
+
+// Bytes packs the EmojiID into TokenCount bytes, one alphabet index per token.
+// The alphabet must have at most 256 entries.
+func (e EmojiID) Bytes(alphabet []rune) ([]byte, error) {
+	if len(alphabet) > 256 {
+		return nil, fmt.Errorf("%w: byte packing needs at most 256 entries, got %d", ErrInvalidAlphabet, len(alphabet))
+	}
+
+	indices, err := e.Indices(alphabet)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, TokenCount)
+	for i, idx := range indices {
+		out[i] = byte(idx)
+	}
+	return out, nil
+}
+
+// FromBytes reverses Bytes.
+func FromBytes(b []byte, alphabet []rune) (EmojiID, error) {
+	if len(b) != TokenCount {
+		return EmojiID{}, ErrInvalidFormat
+	}
+
+	indices := make([]int, TokenCount)
+	for i, v := range b {
+		indices[i] = int(v)
+	}
+	return FromIndices(indices, alphabet)
+}
+
+// Uint16Indices returns the token indices in alphabet as a fixed-size vector, for
+// numeric columnar formats such as Parquet or Arrow. The alphabet must have at
+// most 65536 entries so that every index fits in a uint16.
+func (e EmojiID) Uint16Indices(alphabet []rune) ([TokenCount]uint16, error) {
+	if len(alphabet) > 1<<16 {
+		return [TokenCount]uint16{}, fmt.Errorf("%w: uint16 indices need at most 65536 entries, got %d", ErrInvalidAlphabet, len(alphabet))
+	}
+	indices, err := e.Indices(alphabet)
+	if err != nil {
+		return [TokenCount]uint16{}, err
+	}
+
+	var out [TokenCount]uint16
+	for i, idx := range indices {
+		out[i] = uint16(idx)
+	}
+	return out, nil
+}
+
+// FromUint16Indices reverses Uint16Indices.
+func FromUint16Indices(indices [TokenCount]uint16, alphabet []rune) (EmojiID, error) {
+	ints := make([]int, TokenCount)
+	for i, v := range indices {
+		ints[i] = int(v)
+	}
+	return FromIndices(ints, alphabet)
+}
+
+// Pair returns the display and storage forms of the ID together, for the common
+// store-binary, show-emoji pattern: display is String and storage is Bytes with
+// the same alphabet, so the two cannot drift apart. FromBytes with that alphabet
+// restores the ID from storage.
+func (e EmojiID) Pair(alphabet []rune) (display string, storage []byte, err error) {
+	storage, err = e.Bytes(alphabet)
+	if err != nil {
+		return "", nil, err
+	}
+	return e.String(), storage, nil
+}
+
+// crockford is Crockford's base32 alphabet as used by ULID, without padding.
+var crockford = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// ToBase32 encodes Bytes(alphabet) as 52 characters of Crockford base32, for
+// ULID-centric tooling. The round trip through FromBase32 is lossless when the
+// alphabet has at most 256 entries and no duplicates, and the same alphabet is
+// used on both sides.
+func (e EmojiID) ToBase32(alphabet []rune) (string, error) {
+	b, err := e.Bytes(alphabet)
+	if err != nil {
+		return "", err
+	}
+	return crockford.EncodeToString(b), nil
+}
+
+// FromBase32 decodes a string produced by ToBase32. Following Crockford, it is
+// case-insensitive, reads I and L as 1 and O as 0, and ignores hyphens.
+func FromBase32(s string, alphabet []rune) (EmojiID, error) {
+	s = strings.NewReplacer("-", "", "I", "1", "L", "1", "O", "0").Replace(strings.ToUpper(s))
+
+	b, err := crockford.DecodeString(s)
+	if err != nil {
+		return EmojiID{}, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return FromBytes(b, alphabet)
+}
+
+// SortKey returns a byte string whose lexical order matches Compare, for
+// databases that cannot order emoji meaningfully. Store it next to the display
+// form and sort on it.
+//
+// Each token becomes its big-endian rank among the alphabet's entries sorted by
+// code point: one byte per token for alphabets of up to 256 distinct entries, two
+// bytes otherwise. Keys are only comparable when computed with the same alphabet,
+// and every token must be in it.
+func (e EmojiID) SortKey(alphabet []rune) ([]byte, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+
+	sorted := sortedAlphabet(alphabet)
+	if len(sorted) > 1<<16 {
+		return nil, fmt.Errorf("%w: sort keys support at most 65536 entries, got %d", ErrInvalidAlphabet, len(sorted))
+	}
+
+	width := 1
+	if len(sorted) > 256 {
+		width = 2
+	}
+
+	key := make([]byte, 0, TokenCount*width)
+	for i, r := range e.tokens {
+		rank, ok := slices.BinarySearch(sorted, r)
+		if !ok {
+			return nil, &TokenError{Index: i, Token: r}
+		}
+		if width == 2 {
+			key = append(key, byte(rank>>8))
+		}
+		key = append(key, byte(rank))
+	}
+	return key, nil
+}
+
+// FromUint64 maps n to an EmojiID bijectively, e.g. to migrate auto-increment keys.
+// The ID is n written in base len(alphabet) with the least significant digit
+// first, one token per digit, padded with alphabet[0] up to TokenCount tokens, so
+// distinct integers differ within their leading tokens and FromUint64(0) is
+// alphabet[0] repeated. The IDs are predictable by construction and must not be
+// used where unguessable IDs are needed.
+//
+// Every uint64 needs ceil(64/log2(len(alphabet))) tokens: 9 for DefaultAlphabet,
+// 16 for 16 entries, and all 32 for 4 entries. Alphabets with 2 or 3 entries
+// cannot hold every uint64, and FromUint64 returns ErrOverflow for n beyond
+// len(alphabet)^32 - 1.
+func FromUint64(n uint64, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	base := uint64(len(alphabet))
+	var id EmojiID
+	for i := range id.tokens {
+		id.tokens[i] = alphabet[n%base]
+		n /= base
+	}
+	if n != 0 {
+		return EmojiID{}, fmt.Errorf("%w: integer needs more than %d tokens in a %d-entry alphabet", ErrOverflow, TokenCount, base)
+	}
+	return id, nil
+}
+
+// ToUint64 reverses FromUint64. It returns ErrOverflow if the tokens encode a
+// value above math.MaxUint64, as for most IDs not produced by FromUint64.
+func (e EmojiID) ToUint64(alphabet []rune) (uint64, error) {
+	if len(alphabet) < 2 {
+		return 0, ErrAlphabetTooSmall
+	}
+	indices, err := e.Indices(alphabet)
+	if err != nil {
+		return 0, err
+	}
+
+	base := uint64(len(alphabet))
+	var n uint64
+	for i := TokenCount - 1; i >= 0; i-- {
+		hi, lo := bits.Mul64(n, base)
+		sum, carry := bits.Add64(lo, uint64(indices[i]), 0)
+		if hi != 0 || carry != 0 {
+			return 0, fmt.Errorf("%w: value exceeds 64 bits", ErrOverflow)
+		}
+		n = sum
+	}
+	return n, nil
+}
+
+// halvesAlphabetSize is the alphabet size FromHalves and Halves require: 16
+// entries make each token one hex digit, so 32 tokens hold exactly 128 bits.
+const halvesAlphabetSize = 16
+
+// FromHalves builds an ID from two 64-bit halves, for systems that store 128-bit
+// identifiers in two bigint columns. alphabet must have exactly 16 distinct
+// entries; each token is then one 4-bit digit, most significant first, with hi in
+// the first 16 tokens and lo in the last 16. Halves reverses it.
+func FromHalves(hi, lo uint64, alphabet []rune) (EmojiID, error) {
+	if err := checkHalvesAlphabet(alphabet); err != nil {
+		return EmojiID{}, err
+	}
+
+	var id EmojiID
+	for i := range 16 {
+		shift := 60 - 4*i
+		id.tokens[i] = alphabet[hi>>shift&0xF]
+		id.tokens[16+i] = alphabet[lo>>shift&0xF]
+	}
+	return id, nil
+}
+
+// Halves returns the two 64-bit halves encoded by an ID over a 16-entry alphabet,
+// as built by FromHalves. Any ID over such an alphabet has halves, so New with
+// that alphabet gives IDs with 128 random bits.
+func (e EmojiID) Halves(alphabet []rune) (hi, lo uint64, err error) {
+	if err := checkHalvesAlphabet(alphabet); err != nil {
+		return 0, 0, err
+	}
+	indices, err := e.Indices(alphabet)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range 16 {
+		hi = hi<<4 | uint64(indices[i])
+		lo = lo<<4 | uint64(indices[16+i])
+	}
+	return hi, lo, nil
+}
+
+func checkHalvesAlphabet(alphabet []rune) error {
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return err
+	}
+	if len(alphabet) != halvesAlphabetSize {
+		return fmt.Errorf("%w: halves need exactly %d entries, got %d", ErrInvalidAlphabet, halvesAlphabetSize, len(alphabet))
+	}
+	return nil
+}
@@ -0,0 +1,441 @@
+package emojid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	id := MustNew()
+	data, err := json.Marshal(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got EmojiID
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("round trip = %v, want %v", got, id)
+	}
+}
+
+func TestMarshalJSONVerbose(t *testing.T) {
+	id := MustNew()
+	data, err := id.MarshalJSONVerbose()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		ID           *string  `json:"id"`
+		AlphabetSize *int     `json:"alphabet_size"`
+		EntropyBits  *float64 `json:"entropy_bits"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ID == nil || v.AlphabetSize == nil || v.EntropyBits == nil {
+		t.Fatalf("missing fields in %s", data)
+	}
+	if *v.ID != id.String() {
+		t.Errorf("id = %q, want %q", *v.ID, id.String())
+	}
+	if *v.AlphabetSize != len(DefaultAlphabet) {
+		t.Errorf("alphabet_size = %d, want %d", *v.AlphabetSize, len(DefaultAlphabet))
+	}
+	if want := 32 * math.Log2(152); math.Abs(*v.EntropyBits-want) > 1e-9 {
+		t.Errorf("entropy_bits = %v, want %v", *v.EntropyBits, want)
+	}
+}
+
+func TestMarshalJSONVerboseErrors(t *testing.T) {
+	if _, err := MustNew().MarshalJSONVerboseWithAlphabet(testFaces); err == nil {
+		t.Error("token outside alphabet: no error")
+	}
+
+	data, err := Nil.MarshalJSONVerboseWithAlphabet(testFaces)
+	if err != nil {
+		t.Fatalf("Nil: %v", err)
+	}
+	var v struct{ ID string }
+	if err := json.Unmarshal(data, &v); err != nil || v.ID != "" {
+		t.Errorf("Nil encodes as %s, want an empty id", data)
+	}
+}
+
+func TestBase32RoundTrip(t *testing.T) {
+	for range 50 {
+		id := MustNew()
+		s, err := id.ToBase32(DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s) != 52 {
+			t.Errorf("ToBase32 length %d, want 52", len(s))
+		}
+		got, err := FromBase32(strings.ToLower(s), DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != id {
+			t.Fatalf("round trip of %q = %v, want %v", s, got, id)
+		}
+	}
+
+	if _, err := FromBase32("not base32!", DefaultAlphabet); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("garbage: got %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestBytesAndIndicesRoundTrip(t *testing.T) {
+	id := MustNew()
+	b, err := id.Bytes(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := FromBytes(b, DefaultAlphabet); err != nil || got != id {
+		t.Errorf("FromBytes = %v, %v, want %v", got, err, id)
+	}
+
+	indices, err := id.Indices(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := FromIndices(indices, DefaultAlphabet); err != nil || got != id {
+		t.Errorf("FromIndices = %v, %v, want %v", got, err, id)
+	}
+
+	if _, err := FromIndices(indices[:31], DefaultAlphabet); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("31 indices: got %v, want ErrInvalidFormat", err)
+	}
+	indices[3] = len(DefaultAlphabet)
+	if _, err := FromIndices(indices, DefaultAlphabet); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("index out of range: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestSortKeyMatchesCompare(t *testing.T) {
+	ids := make([]EmojiID, 64)
+	for i := range ids {
+		ids[i] = MustNew()
+	}
+	// Share a prefix so some comparisons are decided late in the ID.
+	ids[1].tokens = ids[0].tokens
+	ids[1].tokens[TokenCount-1] = DefaultAlphabet[0]
+	ids[0].tokens[TokenCount-1] = DefaultAlphabet[len(DefaultAlphabet)-1]
+
+	for _, a := range ids {
+		ka, err := a.SortKey(DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ka) != TokenCount {
+			t.Fatalf("key length = %d, want %d", len(ka), TokenCount)
+		}
+		for _, b := range ids {
+			kb, err := b.SortKey(DefaultAlphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := bytes.Compare(ka, kb), a.Compare(b); got != want {
+				t.Fatalf("bytes.Compare = %d, Compare = %d for %v and %v", got, want, a, b)
+			}
+		}
+	}
+}
+
+func TestSortKeyErrors(t *testing.T) {
+	id := MustNew()
+	if _, err := id.SortKey(testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token outside alphabet: got %v, want ErrInvalidToken", err)
+	}
+	if _, err := id.SortKey([]rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestZeroValueEncoding(t *testing.T) {
+	var zero EmojiID
+	if strings.ContainsRune(zero.String(), 0) || zero.String() != "" {
+		t.Errorf("zero String() = %q, want empty", zero.String())
+	}
+	if s := zero.FormatWithSeparator(' '); s != "" {
+		t.Errorf("zero FormatWithSeparator = %q, want empty", s)
+	}
+
+	text, err := zero.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("MarshalText = %q, %v; want empty", text, err)
+	}
+	got := MustNew()
+	if err := got.UnmarshalText(text); err != nil || got != Nil {
+		t.Errorf("UnmarshalText(%q) = %v, %v; want Nil", text, got, err)
+	}
+
+	data, err := json.Marshal(zero)
+	if err != nil || string(data) != `""` {
+		t.Errorf("json.Marshal = %s, %v; want \"\"", data, err)
+	}
+	got = MustNew()
+	if err := json.Unmarshal(data, &got); err != nil || got != Nil {
+		t.Errorf("json.Unmarshal(%s) = %v, %v; want Nil", data, got, err)
+	}
+}
+
+func TestUint64RoundTrip(t *testing.T) {
+	for _, alphabet := range [][]rune{DefaultAlphabet, DefaultAlphabet[:16], DefaultAlphabet[:4]} {
+		for _, n := range []uint64{0, 1, 151, 152, 1 << 32, math.MaxUint64 - 1, math.MaxUint64} {
+			id, err := FromUint64(n, alphabet)
+			if err != nil {
+				t.Fatalf("FromUint64(%d) with %d entries: %v", n, len(alphabet), err)
+			}
+			got, err := id.ToUint64(alphabet)
+			if err != nil || got != n {
+				t.Errorf("%d entries: ToUint64(FromUint64(%d)) = %d, %v", len(alphabet), n, got, err)
+			}
+		}
+	}
+
+	zero, err := FromUint64(0, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Repeat(string(DefaultAlphabet[0]), TokenCount); string(zero.Tokens()) != want {
+		t.Errorf("FromUint64(0) = %v, want alphabet[0] repeated", zero)
+	}
+}
+
+func TestUint64Overflow(t *testing.T) {
+	if _, err := FromUint64(1<<32, []rune{'🍎', '🍊'}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("2^32 in base 2: got %v, want ErrOverflow", err)
+	}
+	if _, err := FromUint64(1<<32-1, []rune{'🍎', '🍊'}); err != nil {
+		t.Errorf("2^32-1 in base 2: %v", err)
+	}
+
+	var top EmojiID
+	for i := range top.tokens {
+		top.tokens[i] = DefaultAlphabet[len(DefaultAlphabet)-1]
+	}
+	if _, err := top.ToUint64(DefaultAlphabet); !errors.Is(err, ErrOverflow) {
+		t.Errorf("largest ID: got %v, want ErrOverflow", err)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	id := MustNew()
+	var b bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&b, nil))
+	logger.Info("issued", "id", id, "missing", Nil)
+
+	var entry map[string]any
+	if err := json.Unmarshal(b.Bytes(), &entry); err != nil {
+		t.Fatalf("%v: %s", err, b.Bytes())
+	}
+	if entry["id"] != id.String() {
+		t.Errorf("id = %v, want %q", entry["id"], id.String())
+	}
+	if entry["missing"] != "nil" {
+		t.Errorf("Nil logged as %v, want \"nil\"", entry["missing"])
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	want := MustNew()
+	var id EmojiID
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&id, "id", "resource ID")
+
+	if err := fs.Parse([]string{"-id", want.String()}); err != nil {
+		t.Fatal(err)
+	}
+	if id != want {
+		t.Errorf("flag = %v, want %v", id, want)
+	}
+
+	if err := fs.Parse([]string{"-id", "not-an-id"}); err == nil {
+		t.Error("invalid flag value accepted")
+	}
+	if id != want {
+		t.Errorf("failed Set changed the ID to %v", id)
+	}
+}
+
+func TestEmojiIDValue(t *testing.T) {
+	var id EmojiID
+	v := NewEmojiIDValue(&id)
+	if v.Type() != "emojid" {
+		t.Errorf("Type() = %q, want emojid", v.Type())
+	}
+	if v.String() != "" {
+		t.Errorf("String() of Nil = %q, want empty", v.String())
+	}
+
+	want := MustNew()
+	if err := v.Set(want.String()); err != nil {
+		t.Fatal(err)
+	}
+	if id != want || v.String() != want.String() {
+		t.Errorf("after Set: id %v, String() %q; want %v", id, v.String(), want)
+	}
+	if err := v.Set(""); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Set(\"\"): got %v, want ErrInvalidFormat", err)
+	}
+
+	var zero EmojiIDValue
+	if zero.String() != "" {
+		t.Errorf("zero EmojiIDValue String() = %q, want empty", zero.String())
+	}
+}
+
+func TestPair(t *testing.T) {
+	id := MustNew()
+	display, storage, err := id.Pair(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if display != id.String() {
+		t.Errorf("display = %q, want %q", display, id.String())
+	}
+	fromStorage, err := FromBytes(storage, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromDisplay, err := Parse(display)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromStorage != id || fromDisplay != id {
+		t.Errorf("storage gives %v, display gives %v; want %v", fromStorage, fromDisplay, id)
+	}
+
+	if display, storage, err := id.Pair(testFaces); !errors.Is(err, ErrInvalidToken) || display != "" || storage != nil {
+		t.Errorf("foreign alphabet: got %q, %v, %v", display, storage, err)
+	}
+}
+
+func TestHalvesRoundTrip(t *testing.T) {
+	hex := DefaultAlphabet[:16]
+	for _, tc := range []struct{ hi, lo uint64 }{
+		{0, 0},
+		{math.MaxUint64, math.MaxUint64},
+		{0x0123456789abcdef, 0xfedcba9876543210},
+	} {
+		id, err := FromHalves(tc.hi, tc.lo, hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hi, lo, err := id.Halves(hex)
+		if err != nil || hi != tc.hi || lo != tc.lo {
+			t.Errorf("Halves(FromHalves(%#x, %#x)) = %#x, %#x, %v", tc.hi, tc.lo, hi, lo, err)
+		}
+	}
+
+	// Digits are most significant first: 0x0123... starts with entries 0, 1, 2.
+	id, err := FromHalves(0x0123456789abcdef, 0, hex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(id.Tokens()[:3]); got != string(hex[:3]) {
+		t.Errorf("first tokens = %q, want %q", got, string(hex[:3]))
+	}
+
+	random, err := NewWithAlphabet(hex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hi, lo, err := random.Halves(hex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back, err := FromHalves(hi, lo, hex); err != nil || back != random {
+		t.Errorf("FromHalves(Halves(%v)) = %v, %v", random, back, err)
+	}
+}
+
+func TestHalvesErrors(t *testing.T) {
+	for _, alphabet := range [][]rune{DefaultAlphabet[:15], DefaultAlphabet[:17], append(DefaultAlphabet[:15:15], DefaultAlphabet[0])} {
+		if _, err := FromHalves(1, 2, alphabet); !errors.Is(err, ErrInvalidAlphabet) {
+			t.Errorf("%d entries: got %v, want ErrInvalidAlphabet", len(alphabet), err)
+		}
+	}
+	if _, _, err := MustNew().Halves(testFaces[:8]); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("8 entries: got %v, want ErrInvalidAlphabet", err)
+	}
+	if _, _, err := MustNew().Halves(DefaultAlphabet[100:116]); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign tokens: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestProtoString(t *testing.T) {
+	for _, id := range []EmojiID{MustNew(), Nil} {
+		s := id.ProtoString()
+		if s != id.String() {
+			t.Errorf("ProtoString() = %q, want %q", s, id.String())
+		}
+		got, err := FromProtoString(s)
+		if err != nil || got != id {
+			t.Errorf("FromProtoString(%q) = %v, %v; want %v", s, got, err, id)
+		}
+	}
+	if _, err := FromProtoString("not an id"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("got %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestUint16IndicesRoundTrip(t *testing.T) {
+	id := MustNew()
+	vec, err := id.Uint16Indices(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := FromUint16Indices(vec, DefaultAlphabet); err != nil || got != id {
+		t.Errorf("round trip = %v, %v; want %v", got, err, id)
+	}
+
+	// The largest alphabet uses the full uint16 range.
+	large := make([]rune, 1<<16)
+	for i := range large {
+		large[i] = rune(0x20000 + i)
+	}
+	indices := make([]int, TokenCount)
+	indices[0], indices[TokenCount-1] = 1<<16-1, 1<<15
+	id, err = FromIndices(indices, large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vec, err = id.Uint16Indices(large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vec[0] != 1<<16-1 || vec[TokenCount-1] != 1<<15 || vec[1] != 0 {
+		t.Errorf("Uint16Indices = %v", vec)
+	}
+	if got, err := FromUint16Indices(vec, large); err != nil || got != id {
+		t.Errorf("large round trip = %v, %v", got, err)
+	}
+}
+
+func TestUint16IndicesErrors(t *testing.T) {
+	id := MustNew()
+	if _, err := id.Uint16Indices(make([]rune, 1<<16+1)); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("65537 entries: got %v, want ErrInvalidAlphabet", err)
+	}
+	if _, err := id.Uint16Indices(testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign tokens: got %v, want ErrInvalidToken", err)
+	}
+	var vec [TokenCount]uint16
+	vec[3] = uint16(len(testFaces))
+	if _, err := FromUint16Indices(vec, testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("index past the alphabet: got %v, want ErrInvalidToken", err)
+	}
+}
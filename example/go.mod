@@ -4,4 +4,6 @@ go 1.24.5
 
 require github.com/pizza-power/emojid v0.0.0
 
+require golang.org/x/crypto v0.45.0 // indirect
+
 replace github.com/pizza-power/emojid => ..
@@ -0,0 +1,56 @@
+package emojid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextFallback formats the ID in plain ASCII for places that cannot display
+// emoji, such as plain-text email: every token becomes its U+XXXX code point,
+// tokens within a group are joined by '.', and groups of the default layout by
+// '-', e.g. "U+1F600.U+1F603...-U+1F60A...". ParseTextFallback reverses it. Nil
+// is formatted as the empty string.
+func (e EmojiID) TextFallback() string {
+	if e.IsZero() {
+		return ""
+	}
+
+	var b strings.Builder
+	for g, group := range e.Groups() {
+		if g > 0 {
+			b.WriteByte('-')
+		}
+		for j, r := range group {
+			if j > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprintf(&b, "%U", r)
+		}
+	}
+	return b.String()
+}
+
+// ParseTextFallback parses the form produced by TextFallback. The "U+" prefix is
+// case-insensitive, and every token must be in alphabet.
+func ParseTextFallback(s string, alphabet []rune) (EmojiID, error) {
+	var b strings.Builder
+	for g, group := range strings.Split(s, "-") {
+		if g > 0 {
+			b.WriteByte('-')
+		}
+		for _, tok := range strings.Split(group, ".") {
+			hex, ok := strings.CutPrefix(strings.ToUpper(tok), "U+")
+			if !ok || len(hex) < 4 || len(hex) > 6 {
+				return EmojiID{}, fmt.Errorf("%w: %q is not a U+XXXX code point", ErrInvalidFormat, tok)
+			}
+			v, err := strconv.ParseUint(hex, 16, 32)
+			if err != nil || !utf8.ValidRune(rune(v)) || v == '-' {
+				return EmojiID{}, fmt.Errorf("%w: %q is not a valid code point", ErrInvalidFormat, tok)
+			}
+			b.WriteRune(rune(v))
+		}
+	}
+	return ParseWithAlphabet(b.String(), alphabet)
+}
@@ -0,0 +1,53 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTextFallbackRoundTrip(t *testing.T) {
+	for range 20 {
+		id := MustNew()
+		s := id.TextFallback()
+		if strings.ContainsFunc(s, func(r rune) bool { return r > 0x7f }) {
+			t.Fatalf("TextFallback() = %q contains non-ASCII", s)
+		}
+		if strings.Count(s, "-") != len(StandardLayout)-1 || strings.Count(s, "U+") != TokenCount {
+			t.Fatalf("TextFallback() = %q", s)
+		}
+		for _, in := range []string{s, strings.ToLower(s)} {
+			got, err := ParseTextFallback(in, DefaultAlphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != id {
+				t.Fatalf("round trip of %q = %v, want %v", in, got, id)
+			}
+		}
+	}
+	if got := Nil.TextFallback(); got != "" {
+		t.Errorf("Nil = %q, want empty", got)
+	}
+}
+
+func TestParseTextFallbackErrors(t *testing.T) {
+	s := MustNew().TextFallback()
+	first, rest, _ := strings.Cut(s, ".")
+	for _, tc := range []struct {
+		in   string
+		want error
+	}{
+		{"", ErrInvalidFormat},
+		{"1F600." + rest, ErrInvalidFormat},
+		{"U+1F6." + rest, ErrInvalidFormat},
+		{"U+1F600X." + rest, ErrInvalidFormat},
+		{"U+D800." + rest, ErrInvalidFormat},
+		{"U+1F550." + rest, ErrInvalidToken},
+		{first + "." + first + "." + rest, ErrInvalidFormat},
+	} {
+		if _, err := ParseTextFallback(tc.in, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("ParseTextFallback(%.20q...): got %v, want %v", tc.in, err, tc.want)
+		}
+	}
+}
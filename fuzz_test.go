@@ -0,0 +1,88 @@
+package emojid
+
+import (
+	"strings"
+	"testing"
+)
+
+// fuzzSeeds returns valid IDs in the default layout together with near-miss
+// mutations of each: a dropped dash, two swapped tokens across a dash, and
+// presentation selectors added after tokens.
+func fuzzSeeds(tb testing.TB) []string {
+	tb.Helper()
+
+	// A fixed key stream keeps the corpus identical from run to run.
+	g, err := NewGeneratorFromReader(DefaultAlphabet, newKeyStream([]byte("emojid fuzz corpus")))
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	seeds := []string{"", "-", "----", strings.Repeat("😀", TokenCount)}
+	for range 8 {
+		id, err := g.New()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		s := id.String()
+		r := []rune(s)
+
+		dropped := strings.Replace(s, "-", "", 1)
+
+		swapped := append([]rune(nil), r...)
+		swapped[7], swapped[8] = swapped[8], swapped[7] // last token of group 1 and the dash
+
+		var selectors strings.Builder
+		for i, t := range r {
+			selectors.WriteRune(t)
+			if i%3 == 0 && t != '-' {
+				selectors.WriteRune('\uFE0F')
+			}
+		}
+
+		seeds = append(seeds, s, dropped, string(swapped), selectors.String(),
+			s+"-", "-"+s, s[:len(s)-1], strings.ToUpper(s))
+	}
+	return seeds
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range fuzzSeeds(f) {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		id, err := Parse(s)
+		if err != nil {
+			if id != Nil {
+				t.Fatalf("Parse(%q) returned %v with error %v", s, id, err)
+			}
+			return
+		}
+
+		// Parse only accepts the canonical form, so formatting reproduces s.
+		if got := id.String(); got != s {
+			t.Fatalf("Parse(%q).String() = %q", s, got)
+		}
+		again, err := Parse(id.String())
+		if err != nil {
+			t.Fatalf("reparse of %q: %v", s, err)
+		}
+		if again != id {
+			t.Fatalf("reparse of %q = %v, want %v", s, again, id)
+		}
+	})
+}
+
+func TestFuzzSeedsIncludeValidAndInvalid(t *testing.T) {
+	var valid, invalid int
+	for _, s := range fuzzSeeds(t) {
+		if _, err := Parse(s); err == nil {
+			valid++
+		} else {
+			invalid++
+		}
+	}
+	if valid == 0 || invalid == 0 {
+		t.Errorf("corpus has %d valid and %d invalid seeds, want both", valid, invalid)
+	}
+}
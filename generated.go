@@ -0,0 +1,73 @@
+package emojid
+
+import "math"
+
+// LooksGenerated is a heuristic for spotting forged or templated IDs: it reports
+// whether the tokens look like a uniform draw from a DefaultAlphabet-sized
+// alphabet, with a confidence score in [0, 1]. It is a screening signal for
+// abuse detection, not proof; an attacker who copies a real ID or draws random
+// tokens passes, and IDs from small custom alphabets score low by design.
+//
+// The score multiplies two factors. The first compares the number of distinct
+// tokens with what 32 uniform draws produce (about 29 of 152), falling off below
+// two standard deviations of that mean. The second penalizes runs of one token:
+// adjacent repeats are common, but a run of three has odds of roughly 1 in 800,
+// and each further repeat lowers the factor. The result is true for scores of at
+// least 0.5, which about 2 in 10,000 genuine IDs miss.
+func (e EmojiID) LooksGenerated() (bool, float64) {
+	if e.IsZero() {
+		return false, 0
+	}
+
+	distinct := len(alphabetSet(e.tokens[:]))
+	mean, sd := distinctTokenStats(len(DefaultAlphabet), TokenCount)
+	distinctScore := min(1, float64(distinct)/(mean-2*sd))
+
+	longest, run := 1, 1
+	for i := 1; i < TokenCount; i++ {
+		if e.tokens[i] == e.tokens[i-1] {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 1
+		}
+	}
+	runScore := 1.0
+	if longest > 2 {
+		runScore = 1 / float64(longest-1)
+	}
+
+	score := distinctScore * runScore
+	return score >= 0.5, score
+}
+
+// distinctTokenStats returns the mean and standard deviation of the number of
+// distinct values among k uniform draws from n.
+func distinctTokenStats(n, k int) (mean, sd float64) {
+	fn, fk := float64(n), float64(k)
+	p1 := math.Pow(1-1/fn, fk)
+	p2 := math.Pow(1-2/fn, fk)
+	mean = fn * (1 - p1)
+	variance := fn*(fn-1)*p2 + fn*p1 - fn*fn*p1*p1
+	return mean, math.Sqrt(variance)
+}
+
+// ObservedEntropy returns the Shannon entropy, in bits per token, of the token
+// frequencies within this one ID: 0 when all 32 tokens are the same and
+// log2(32) = 5 when they are all different. Random IDs from DefaultAlphabet
+// typically score about 4.8. Like LooksGenerated it is a per-ID heuristic for
+// spotting degenerate or templated IDs; it says nothing about how the ID was
+// generated. Nil scores 0.
+func (e EmojiID) ObservedEntropy() float64 {
+	counts := make(map[rune]int, TokenCount)
+	for _, r := range e.tokens {
+		counts[r]++
+	}
+
+	h := 0.0
+	for _, n := range counts {
+		p := float64(n) / TokenCount
+		h -= p * math.Log2(p)
+	}
+	return h
+}
@@ -0,0 +1,85 @@
+package emojid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLooksGenerated(t *testing.T) {
+	misses := 0
+	for range 500 {
+		if ok, score := MustNew().LooksGenerated(); !ok {
+			misses++
+		} else if score < 0.5 || score > 1 {
+			t.Fatalf("score %v out of range", score)
+		}
+	}
+	if misses > 2 {
+		t.Errorf("%d of 500 genuine IDs rejected", misses)
+	}
+
+	repeated, err := FromIndices(make([]int, TokenCount), DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, score := repeated.LooksGenerated(); ok || score > 0.1 {
+		t.Errorf("repeated token: %v, %v", ok, score)
+	}
+
+	var cycled EmojiID
+	for i := range cycled.tokens {
+		cycled.tokens[i] = testFaces[i%len(testFaces)]
+	}
+	if ok, score := cycled.LooksGenerated(); ok {
+		t.Errorf("8 tokens cycled: %v, %v", ok, score)
+	}
+
+	if ok, score := Nil.LooksGenerated(); ok || score != 0 {
+		t.Errorf("Nil: %v, %v", ok, score)
+	}
+}
+
+func TestObservedEntropy(t *testing.T) {
+	if got := Nil.ObservedEntropy(); got != 0 {
+		t.Errorf("Nil = %v, want 0", got)
+	}
+	same, err := FromIndices(make([]int, TokenCount), DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := same.ObservedEntropy(); got != 0 {
+		t.Errorf("one repeated token = %v, want 0", got)
+	}
+
+	distinct := make([]int, TokenCount)
+	for i := range distinct {
+		distinct[i] = i
+	}
+	all, err := FromIndices(distinct, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := all.ObservedEntropy(); math.Abs(got-5) > 1e-9 {
+		t.Errorf("32 distinct tokens = %v, want 5", got)
+	}
+
+	halves := make([]int, TokenCount)
+	for i := range halves {
+		halves[i] = i % 2
+	}
+	two, err := FromIndices(halves, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := two.ObservedEntropy(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("two tokens 16 times each = %v, want 1", got)
+	}
+
+	sum := 0.0
+	for range 200 {
+		sum += MustNew().ObservedEntropy()
+	}
+	if mean := sum / 200; mean < 4.6 || mean > 4.95 {
+		t.Errorf("mean over random IDs = %.2f, want about 4.8", mean)
+	}
+}
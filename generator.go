@@ -0,0 +1,128 @@
+package emojid
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// IDSource produces EmojiIDs. Code that depends on ID generation can accept an
+// IDSource instead of calling New directly, so tests can inject StaticSource.
+type IDSource interface {
+	New() (EmojiID, error)
+}
+
+var (
+	_ IDSource = defaultSource{}
+	_ IDSource = (*Generator)(nil)
+	_ IDSource = (*staticSource)(nil)
+)
+
+// DefaultSource is an IDSource backed by the package-level New.
+var DefaultSource IDSource = defaultSource{}
+
+type defaultSource struct{}
+
+func (defaultSource) New() (EmojiID, error) {
+	return New()
+}
+
+// Generator produces EmojiIDs from a fixed alphabet.
+type Generator struct {
+	alphabet []rune
+	rand     io.Reader
+	stats    *SamplingStats
+}
+
+// NewGenerator returns a Generator that draws from a copy of alphabet using
+// crypto/rand. Alphabet must contain at least 2 entries.
+func NewGenerator(alphabet []rune) (*Generator, error) {
+	return NewGeneratorFromReader(alphabet, rand.Reader)
+}
+
+// NewGeneratorFromReader is like NewGenerator but reads randomness from r, for
+// example a reader wrapped with NewHealthCheckedReader. Read errors surface as
+// ErrEntropyFailure. The generator is safe for concurrent use only if r is.
+func NewGeneratorFromReader(alphabet []rune, r io.Reader) (*Generator, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+	return &Generator{alphabet: append([]rune(nil), alphabet...), rand: r}, nil
+}
+
+// New returns a new random EmojiID from the generator's alphabet.
+func (g *Generator) New() (EmojiID, error) {
+	return newWithSampler(g.alphabet, sampler{r: g.rand, stats: g.stats})
+}
+
+// RecordStats makes the generator count its random draws and rejections in s.
+// Recording is opt-in so the default path pays nothing for it; pass nil to stop.
+// Call it before sharing the generator between goroutines.
+func (g *Generator) RecordStats(s *SamplingStats) {
+	g.stats = s
+}
+
+// SamplingStats counts the random draws made by a Generator and how many of them
+// were rejected to avoid modulo bias. Each draw is a 16-bit value, and a draw is
+// rejected with probability (65536 mod n)/65536 for an alphabet of n entries, so
+// sizes that divide 65536 (powers of two) never reject.
+//
+// A SamplingStats is safe for concurrent use.
+type SamplingStats struct {
+	draws      atomic.Int64
+	rejections atomic.Int64
+}
+
+// Stats returns the number of draws and rejections recorded so far.
+func (s *SamplingStats) Stats() (draws, rejections int) {
+	return int(s.draws.Load()), int(s.rejections.Load())
+}
+
+var (
+	countGenerated atomic.Bool
+	generatedCount atomic.Uint64
+)
+
+// CountGenerated turns the process-wide count of generated IDs on or off. The
+// count covers New, NewWithAlphabet and every package-level helper built on them;
+// Generator instances are not counted. Counting is off by default so the hot path
+// only pays for one atomic load; when on, every ID also costs an atomic add, which
+// can contend across cores under heavy parallel generation.
+func CountGenerated(on bool) {
+	countGenerated.Store(on)
+}
+
+// GeneratedCount returns the number of IDs generated while counting was on.
+func GeneratedCount() uint64 {
+	return generatedCount.Load()
+}
+
+// ResetGeneratedCount sets the count of generated IDs back to zero.
+func ResetGeneratedCount() {
+	generatedCount.Store(0)
+}
+
+// StaticSource returns an IDSource that yields ids in order and then fails with
+// ErrSourceExhausted. It is meant for tests. The returned source is safe for
+// concurrent use.
+func StaticSource(ids ...EmojiID) IDSource {
+	return &staticSource{ids: append([]EmojiID(nil), ids...)}
+}
+
+type staticSource struct {
+	mu  sync.Mutex
+	ids []EmojiID
+}
+
+func (s *staticSource) New() (EmojiID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ids) == 0 {
+		return EmojiID{}, ErrSourceExhausted
+	}
+	id := s.ids[0]
+	s.ids = s.ids[1:]
+	return id, nil
+}
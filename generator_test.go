@@ -0,0 +1,157 @@
+package emojid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestStaticSource(t *testing.T) {
+	ids := []EmojiID{MustNew(), MustNew(), MustNew()}
+	var src IDSource = StaticSource(ids...)
+
+	for i, want := range ids {
+		got, err := src.New()
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("call %d = %v, want %v", i, got, want)
+		}
+	}
+	for range 2 {
+		if _, err := src.New(); !errors.Is(err, ErrSourceExhausted) {
+			t.Errorf("after exhaustion: got %v, want ErrSourceExhausted", err)
+		}
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	alphabet := []rune{'🍎', '🍊'}
+	g, err := NewGenerator(alphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alphabet[0] = 'x' // the generator keeps its own copy
+
+	id, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range id.Tokens() {
+		if r != '🍎' && r != '🍊' {
+			t.Fatalf("token %q not in alphabet", string(r))
+		}
+	}
+
+	if _, err := NewGenerator([]rune{'x'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("tiny alphabet: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestSamplingStatsCountsRejections(t *testing.T) {
+	// 65536 mod 100 = 36, so draws 65500 and above are rejected.
+	alphabet := make([]rune, 100)
+	for i := range alphabet {
+		alphabet[i] = 0x1F300 + rune(i)
+	}
+
+	// Every other draw is 0xFFFF and must be rejected.
+	r := &repeatReader{pattern: []byte{0xFF, 0xFF, 0x00, 0x07}}
+	g, err := NewGeneratorFromReader(alphabet, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats SamplingStats
+	g.RecordStats(&stats)
+
+	if _, err := g.New(); err != nil {
+		t.Fatal(err)
+	}
+	draws, rejections := stats.Stats()
+	if draws != 2*TokenCount || rejections != TokenCount {
+		t.Errorf("Stats() = %d draws, %d rejections, want %d and %d", draws, rejections, 2*TokenCount, TokenCount)
+	}
+}
+
+func TestSamplingStatsPowerOfTwoNeverRejects(t *testing.T) {
+	g, err := NewGenerator(DefaultAlphabet[:128])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats SamplingStats
+	g.RecordStats(&stats)
+	for range 10 {
+		if _, err := g.New(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if draws, rejections := stats.Stats(); draws != 10*TokenCount || rejections != 0 {
+		t.Errorf("Stats() = %d draws, %d rejections, want %d and 0", draws, rejections, 10*TokenCount)
+	}
+}
+
+// repeatReader yields pattern over and over.
+type repeatReader struct {
+	pattern []byte
+	off     int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.pattern[r.off]
+		r.off = (r.off + 1) % len(r.pattern)
+	}
+	return len(p), nil
+}
+
+func BenchmarkGeneratorNew(b *testing.B) {
+	g, err := NewGenerator(DefaultAlphabet)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var stats SamplingStats
+	g.RecordStats(&stats)
+	for b.Loop() {
+		if _, err := g.New(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	draws, rejections := stats.Stats()
+	b.ReportMetric(float64(rejections)/float64(draws), "rejections/draw")
+}
+
+func TestCountGeneratedConcurrent(t *testing.T) {
+	CountGenerated(true)
+	ResetGeneratedCount()
+	t.Cleanup(func() {
+		CountGenerated(false)
+		ResetGeneratedCount()
+	})
+
+	const workers, perWorker = 16, 250
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				if _, err := New(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := GeneratedCount(); got != workers*perWorker {
+		t.Errorf("GeneratedCount() = %d, want %d", got, workers*perWorker)
+	}
+
+	CountGenerated(false)
+	MustNew()
+	if got := GeneratedCount(); got != workers*perWorker {
+		t.Errorf("count moved to %d while off", got)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/pizza-power/emojid
 
 go 1.24.5
+
+require golang.org/x/crypto v0.45.0
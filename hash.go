@@ -0,0 +1,83 @@
+package emojid
+
+import "fmt"
+
+// Hash64 returns a stable 64-bit hash of the tokens. It is fast and well mixed,
+// which makes it suitable for sharding and probabilistic data structures, but it
+// is not a cryptographic hash.
+func (e EmojiID) Hash64() uint64 {
+	return e.hash64(0)
+}
+
+// hash64 is FNV-1a over the little-endian token code points, started from a
+// salted offset and finished with the murmur3 64-bit finalizer.
+func (e EmojiID) hash64(salt uint64) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+
+	h := uint64(offset64) ^ mix64(salt)
+	for _, r := range e.tokens {
+		v := uint32(r)
+		for i := 0; i < 4; i++ {
+			h ^= uint64(byte(v >> (8 * i)))
+			h *= prime64
+		}
+	}
+	return mix64(h)
+}
+
+// mix64 is the murmur3 fmix64 finalizer.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// Color returns a stable RGB color derived from the tokens, e.g. for avatar
+// backgrounds. The same ID always yields the same color, and the channels come
+// from independent bytes of Hash64, so distinct IDs are spread evenly over the
+// 16.7 million colors.
+func (e EmojiID) Color() (r, g, b uint8) {
+	h := e.Hash64()
+	return uint8(h >> 16), uint8(h >> 8), uint8(h)
+}
+
+// ColorHex returns Color as a CSS hex string such as "#1a2b3c".
+func (e EmojiID) ColorHex() string {
+	r, g, b := e.Color()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// fingerprintSalt separates Fingerprint from Hash64 and the BloomSet hashes.
+const fingerprintSalt = 0x66707269 // "fpri"
+
+// Fingerprint returns a short 4-emoji digest of the whole ID for logs, like a git
+// short hash: the same ID always gives the same fingerprint, and every token of
+// the ID affects it. It is derived from a salted Hash64 rather than being a prefix,
+// so IDs sharing a prefix still get unrelated fingerprints.
+//
+// With DefaultAlphabet there are 152^4, about 5.3e8, fingerprints, so two given
+// IDs share one with probability about 1.9e-9, and among 27,000 IDs there is a 50%
+// chance that some pair does. Use it for scanning, never as a key. It returns ""
+// for alphabets with fewer than 2 entries and for Nil.
+func (e EmojiID) Fingerprint(alphabet []rune) string {
+	if len(alphabet) < 2 || e.IsZero() {
+		return ""
+	}
+
+	// Four base-n digits of a 64-bit hash; for alphabets of up to 2^16 entries the
+	// modulo bias is negligible.
+	h := e.hash64(fingerprintSalt)
+	n := uint64(len(alphabet))
+	var out [4]rune
+	for i := range out {
+		out[i] = alphabet[h%n]
+		h /= n
+	}
+	return string(out[:])
+}
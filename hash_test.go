@@ -0,0 +1,60 @@
+package emojid
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	id := MustNew()
+	r, g, b := id.Color()
+	r2, g2, b2 := id.Color()
+	if r != r2 || g != g2 || b != b2 {
+		t.Error("Color is not deterministic")
+	}
+	copied := id
+	if copied.ColorHex() != id.ColorHex() {
+		t.Error("equal IDs have different colors")
+	}
+	if !regexp.MustCompile(`^#[0-9a-f]{6}$`).MatchString(id.ColorHex()) {
+		t.Errorf("ColorHex() = %q", id.ColorHex())
+	}
+
+	colors := make(map[string]bool)
+	for range 100 {
+		colors[MustNew().ColorHex()] = true
+	}
+	if len(colors) < 95 {
+		t.Errorf("100 IDs gave only %d colors", len(colors))
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	id := MustNew()
+	fp := id.Fingerprint(DefaultAlphabet)
+	if n := len([]rune(fp)); n != 4 {
+		t.Fatalf("Fingerprint() = %q has %d tokens, want 4", fp, n)
+	}
+	if id.Fingerprint(DefaultAlphabet) != fp {
+		t.Error("Fingerprint is not deterministic")
+	}
+	for _, r := range fp {
+		if !slices.Contains(DefaultAlphabet, r) {
+			t.Errorf("%q is not in the alphabet", string(r))
+		}
+	}
+
+	// Every token matters, including the last one.
+	other := id
+	other.tokens[TokenCount-1] = DefaultAlphabet[0]
+	if other.tokens[TokenCount-1] == id.tokens[TokenCount-1] {
+		other.tokens[TokenCount-1] = DefaultAlphabet[1]
+	}
+	if other.Fingerprint(DefaultAlphabet) == fp {
+		t.Error("changing the last token kept the fingerprint")
+	}
+	if Nil.Fingerprint(DefaultAlphabet) != "" || id.Fingerprint([]rune{'😀'}) != "" {
+		t.Error("Nil or a 1-entry alphabet gave a fingerprint")
+	}
+}
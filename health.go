@@ -0,0 +1,65 @@
+package emojid
+
+import (
+	"io"
+	"sync"
+)
+
+// healthRepeatLimit is the number of identical consecutive 16-bit words that
+// trips the health check. A healthy source does this with probability 2^-48 per
+// word.
+const healthRepeatLimit = 4
+
+// NewHealthCheckedReader wraps r with a continuous random number generator test
+// in the spirit of FIPS 140-2's CRNGT: the output is read as 16-bit words, and once
+// the same word has been returned healthRepeatLimit times in a row, that Read and
+// every later one fails with ErrEntropyFailure. It catches sources that are stuck
+// on a constant value, such as a broken device returning all zeros.
+//
+// This is a defense-in-depth check, not a statistical guarantee: a source can pass
+// it and still be badly biased or predictable. Use it with
+// NewGeneratorFromReader. The returned reader is safe for concurrent use if r is.
+func NewHealthCheckedReader(r io.Reader) io.Reader {
+	return &healthCheckedReader{r: r}
+}
+
+type healthCheckedReader struct {
+	r io.Reader
+
+	mu         sync.Mutex
+	pending    byte // first byte of an incomplete word
+	hasPending bool
+	prev       uint16
+	run        int
+	failed     bool
+}
+
+func (h *healthCheckedReader) Read(p []byte) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failed {
+		return 0, ErrEntropyFailure
+	}
+
+	n, err := h.r.Read(p)
+	for _, b := range p[:n] {
+		if !h.hasPending {
+			h.pending, h.hasPending = b, true
+			continue
+		}
+		h.hasPending = false
+
+		w := uint16(h.pending)<<8 | uint16(b)
+		if h.run > 0 && w == h.prev {
+			h.run++
+		} else {
+			h.prev, h.run = w, 1
+		}
+		if h.run >= healthRepeatLimit {
+			h.failed = true
+			return 0, ErrEntropyFailure
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,52 @@
+package emojid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+// constantReader returns the same byte forever.
+type constantReader byte
+
+func (c constantReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(c)
+	}
+	return len(p), nil
+}
+
+func TestHealthCheckedReaderConstantSource(t *testing.T) {
+	r := NewHealthCheckedReader(constantReader(0))
+	if _, err := r.Read(make([]byte, 2*healthRepeatLimit)); !errors.Is(err, ErrEntropyFailure) {
+		t.Fatalf("constant source: got %v, want ErrEntropyFailure", err)
+	}
+	// The failure is sticky.
+	if _, err := r.Read(make([]byte, 2)); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("after failure: got %v, want ErrEntropyFailure", err)
+	}
+
+	g, err := NewGeneratorFromReader(DefaultAlphabet, NewHealthCheckedReader(constantReader(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New(); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("generator on a constant source: got %v, want ErrEntropyFailure", err)
+	}
+}
+
+func TestHealthCheckedReaderHealthySource(t *testing.T) {
+	r := NewHealthCheckedReader(rand.Reader)
+	if _, err := io.ReadFull(r, make([]byte, 1<<16)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs shorter than the limit pass, even split across reads.
+	short := bytes.Repeat([]byte{1, 2}, healthRepeatLimit-1)
+	r = NewHealthCheckedReader(io.MultiReader(bytes.NewReader(short[:3]), bytes.NewReader(short[3:])))
+	if _, err := io.ReadAll(r); err != nil {
+		t.Errorf("%d repeats: %v", healthRepeatLimit-1, err)
+	}
+}
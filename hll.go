@@ -0,0 +1,73 @@
+package emojid
+
+import (
+	"math"
+	"math/bits"
+)
+
+// HLL is a HyperLogLog counter estimating how many distinct EmojiIDs have been
+// added, in constant memory: one byte per register, 2^precision registers.
+//
+// The relative standard error is about 1.04/sqrt(2^precision): 1.6% with 4 KiB
+// at precision 12, 0.8% with 16 KiB at 14. Duplicates never change the estimate,
+// and small counts are corrected with linear counting, so they are close to
+// exact.
+//
+// An HLL is not safe for concurrent use.
+type HLL struct {
+	registers []uint8
+	p         uint
+}
+
+// NewHLL returns an empty HLL with 2^precision registers. precision is clamped
+// to [4, 18].
+func NewHLL(precision int) *HLL {
+	p := uint(max(4, min(precision, 18)))
+	return &HLL{
+		registers: make([]uint8, 1<<p),
+		p:         p,
+	}
+}
+
+// Add records id. The top precision bits of Hash64 select a register, which keeps
+// the longest run of leading zeros seen in the remaining bits.
+func (h *HLL) Add(id EmojiID) {
+	x := id.Hash64()
+	idx := x >> (64 - h.p)
+	// The sentinel bit caps the rank at 64-p+1 when the remaining bits are zero.
+	rank := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1)) + 1)
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Estimate returns the estimated number of distinct IDs added.
+func (h *HLL) Estimate() float64 {
+	m := float64(len(h.registers))
+
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	e := alpha * m * m / sum
+
+	if e <= 2.5*m && zeros > 0 {
+		return m * math.Log(m/float64(zeros))
+	}
+	return e
+}
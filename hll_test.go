@@ -0,0 +1,54 @@
+package emojid
+
+import (
+	"math"
+	"testing"
+)
+
+// sequentialID returns a distinct ID for each n, so estimates are reproducible.
+func sequentialID(t testing.TB, n int) EmojiID {
+	t.Helper()
+	id, err := FromUint64(uint64(n), DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestHLLEstimate(t *testing.T) {
+	for _, n := range []int{1000, 100000} {
+		h := NewHLL(12)
+		for i := range n {
+			h.Add(sequentialID(t, i))
+		}
+		// Four standard errors of 1.6% each.
+		if got := h.Estimate(); math.Abs(got-float64(n))/float64(n) > 0.065 {
+			t.Errorf("%d IDs: estimate %.0f", n, got)
+		}
+	}
+}
+
+func TestHLLSmallAndDuplicates(t *testing.T) {
+	h := NewHLL(14)
+	if got := h.Estimate(); got != 0 {
+		t.Errorf("empty estimate = %v, want 0", got)
+	}
+	for range 10 {
+		for i := range 50 {
+			h.Add(sequentialID(t, i))
+		}
+	}
+	if got := h.Estimate(); math.Abs(got-50) > 1 {
+		t.Errorf("50 distinct IDs added 10 times: estimate %.2f", got)
+	}
+}
+
+func TestNewHLLClampsPrecision(t *testing.T) {
+	for _, tc := range []struct{ precision, registers int }{
+		{0, 1 << 4}, {-3, 1 << 4}, {10, 1 << 10}, {30, 1 << 18},
+	} {
+		if got := len(NewHLL(tc.precision).registers); got != tc.registers {
+			t.Errorf("NewHLL(%d) has %d registers, want %d", tc.precision, got, tc.registers)
+		}
+	}
+}
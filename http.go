@@ -0,0 +1,42 @@
+package emojid
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestIDHeader is the response header set by EmojiIDMiddleware.
+const RequestIDHeader = "X-Request-Id"
+
+// contextKey is the context key for the request's EmojiID.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying id, for FromContext.
+func NewContext(ctx context.Context, id EmojiID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the EmojiID stored in ctx by NewContext or
+// EmojiIDMiddleware, if any.
+func FromContext(ctx context.Context) (EmojiID, bool) {
+	id, ok := ctx.Value(contextKey{}).(EmojiID)
+	return id, ok
+}
+
+// EmojiIDMiddleware tags every request with a fresh EmojiID from New: it sets the
+// ID on the RequestIDHeader response header and stores it in the request context,
+// where handlers read it with FromContext. The header value is UTF-8, which
+// net/http sends as is; clients that only accept ASCII headers need a different
+// encoding. If generation fails the request is answered with 500 and next is not
+// called.
+func EmojiIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := New()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set(RequestIDHeader, id.String())
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
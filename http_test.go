@@ -0,0 +1,45 @@
+package emojid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmojiIDMiddleware(t *testing.T) {
+	var seen []EmojiID
+	h := EmojiIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := FromContext(r.Context())
+		if !ok {
+			t.Error("no ID in the request context")
+		}
+		seen = append(seen, id)
+	}))
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		header := rec.Header().Get(RequestIDHeader)
+		id, err := Parse(header)
+		if err != nil {
+			t.Fatalf("header %q: %v", header, err)
+		}
+		if id != seen[len(seen)-1] {
+			t.Errorf("header %v differs from context %v", id, seen[len(seen)-1])
+		}
+	}
+	if seen[0] == seen[1] {
+		t.Error("two requests got the same ID")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("empty context has an ID")
+	}
+	id := MustNew()
+	if got, ok := FromContext(NewContext(context.Background(), id)); !ok || got != id {
+		t.Errorf("FromContext = %v, %v; want %v", got, ok, id)
+	}
+}
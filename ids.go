@@ -0,0 +1,141 @@
+package emojid
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+// EmojiIDs is a list of EmojiIDs that encodes as a JSON array of canonical strings.
+type EmojiIDs []EmojiID
+
+// MarshalJSON encodes the list as a JSON array of canonical strings.
+func (ids EmojiIDs) MarshalJSON() ([]byte, error) {
+	if ids == nil {
+		return []byte("null"), nil
+	}
+
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return json.Marshal(strs)
+}
+
+// UnmarshalJSON decodes a JSON array of strings, parsing each with Parse. As with
+// EmojiID.UnmarshalText, an empty string decodes to Nil.
+func (ids *EmojiIDs) UnmarshalJSON(data []byte) error {
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	if strs == nil {
+		*ids = nil
+		return nil
+	}
+
+	out := make(EmojiIDs, len(strs))
+	for i, s := range strs {
+		id, err := ParseAllowNil(s, defaultAlphabet())
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = id
+	}
+	*ids = out
+	return nil
+}
+
+// Contains reports whether id is in the list.
+func (ids EmojiIDs) Contains(id EmojiID) bool {
+	return slices.ContainsFunc(ids, id.Equal)
+}
+
+// Sort sorts the list in place in Compare order.
+func (ids EmojiIDs) Sort() {
+	slices.SortFunc(ids, EmojiID.Compare)
+}
+
+// MinDistinguishingPrefix returns the smallest k such that the first k tokens of
+// every ID in ids are unique across the set, like the length of a git short hash.
+// It returns 0 for fewer than two IDs and TokenCount if some IDs are identical.
+func MinDistinguishingPrefix(ids []EmojiID) int {
+	if len(ids) < 2 {
+		return 0
+	}
+
+	// Uniqueness is monotonic in k, so binary search for the first unique length.
+	lo, hi := 1, TokenCount
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if prefixesUnique(ids, mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// prefixesUnique reports whether the first k tokens differ between all ids.
+func prefixesUnique(ids []EmojiID, k int) bool {
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		p := string(id.tokens[:k])
+		if _, dup := seen[p]; dup {
+			return false
+		}
+		seen[p] = struct{}{}
+	}
+	return true
+}
+
+// PrefixCollisions maps the first prefixLen tokens of each ID in ids, as a string
+// without separators, to the number of IDs starting with it. With a uniform
+// generator the counts follow a balls-into-bins distribution, so a prefix shared
+// far more often than that suggests clustering or a biased source. prefixLen is
+// clamped to [0, TokenCount].
+func PrefixCollisions(ids []EmojiID, prefixLen int) map[string]int {
+	prefixLen = max(0, min(prefixLen, TokenCount))
+
+	counts := make(map[string]int)
+	for _, id := range ids {
+		counts[string(id.tokens[:prefixLen])]++
+	}
+	return counts
+}
+
+// ParseConcatenated splits s, count IDs written back to back without separators
+// as some packed legacy stores do, into consecutive runs of 32 tokens and checks
+// every token against alphabet. s must hold exactly count*32 tokens. A bad token
+// is reported as a *TokenError with its position within its ID, wrapped with the
+// ID's index in the stream.
+func ParseConcatenated(s string, count int, alphabet []rune) ([]EmojiID, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+	if !utf8.ValidString(s) {
+		return nil, ErrInvalidUTF8
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("%w: negative count %d", ErrInvalidFormat, count)
+	}
+	// Compare by division: count*TokenCount can overflow for a hostile count.
+	if n := utf8.RuneCountInString(s); n%TokenCount != 0 || n/TokenCount != count {
+		return nil, fmt.Errorf("%w: %d tokens, want %d per ID for %d IDs", ErrInvalidFormat, n, TokenCount, count)
+	}
+	allowed := cachedAlphabetSet(alphabet)
+
+	ids := make([]EmojiID, count)
+	i := 0
+	for _, r := range s {
+		id, pos := i/TokenCount, i%TokenCount
+		if _, ok := allowed[r]; !ok {
+			return nil, fmt.Errorf("id %d: %w", id, &TokenError{Index: pos, Token: r})
+		}
+		ids[id].tokens[pos] = r
+		i++
+	}
+	return ids, nil
+}
@@ -0,0 +1,171 @@
+package emojid
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEmojiIDsJSON(t *testing.T) {
+	ids := EmojiIDs{MustNew(), MustNew(), Nil}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got EmojiIDs
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, ids) {
+		t.Errorf("round trip = %v, want %v", got, ids)
+	}
+
+	var none EmojiIDs
+	if data, _ := json.Marshal(none); string(data) != "null" {
+		t.Errorf("nil list encodes as %s, want null", data)
+	}
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != nil {
+		t.Errorf("null decodes to %v, %v", got, err)
+	}
+}
+
+func TestEmojiIDsUnmarshalErrors(t *testing.T) {
+	var ids EmojiIDs
+	for _, in := range []string{`{"a":1}`, `[1]`, `["` + MustNewString() + `","nope"]`} {
+		if err := json.Unmarshal([]byte(in), &ids); err == nil {
+			t.Errorf("Unmarshal(%s): no error", in)
+		}
+	}
+}
+
+func TestEmojiIDsHelpers(t *testing.T) {
+	ids := EmojiIDs{MustNew(), MustNew(), MustNew(), MustNew()}
+	if !ids.Contains(ids[2]) {
+		t.Error("Contains misses a member")
+	}
+	if ids.Contains(MustNew()) {
+		t.Error("Contains reports a random ID")
+	}
+
+	ids.Sort()
+	if !slices.IsSortedFunc(ids, EmojiID.Compare) {
+		t.Errorf("Sort left %v unsorted", ids)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a, b := MustNew(), MustNew()
+	if a.Compare(a) != 0 {
+		t.Error("Compare(a, a) != 0")
+	}
+	if a.Compare(b) != -b.Compare(a) {
+		t.Error("Compare is not antisymmetric")
+	}
+}
+
+// idWithPrefix returns an ID whose first tokens are prefix and the rest random.
+func idWithPrefix(t *testing.T, prefix []rune) EmojiID {
+	t.Helper()
+	id := MustNew()
+	for i, r := range prefix {
+		if err := id.SetTokenAt(i, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return id
+}
+
+func TestMinDistinguishingPrefix(t *testing.T) {
+	shared := []rune("😀😃😄😁😆😅😂🤣😊😇")
+	a := idWithPrefix(t, append(shared, '🐶'))
+	b := idWithPrefix(t, append(shared, '🐱'))
+	c := idWithPrefix(t, []rune("🐶"))
+
+	if got := MinDistinguishingPrefix([]EmojiID{a, b, c}); got != len(shared)+1 {
+		t.Errorf("MinDistinguishingPrefix = %d, want %d", got, len(shared)+1)
+	}
+	if got := MinDistinguishingPrefix([]EmojiID{a}); got != 0 {
+		t.Errorf("single ID: got %d, want 0", got)
+	}
+	if got := MinDistinguishingPrefix([]EmojiID{a, a}); got != TokenCount {
+		t.Errorf("identical IDs: got %d, want %d", got, TokenCount)
+	}
+	if got := MinDistinguishingPrefix([]EmojiID{a, c}); got != 1 {
+		t.Errorf("different first tokens: got %d, want 1", got)
+	}
+}
+
+func TestPrefixCollisions(t *testing.T) {
+	a := idWithPrefix(t, []rune{'😀', '😃'})
+	b := idWithPrefix(t, []rune{'😀', '😃'})
+	c := idWithPrefix(t, []rune{'😀', '🐶'})
+
+	counts := PrefixCollisions([]EmojiID{a, b, c}, 2)
+	want := map[string]int{"😀😃": 2, "😀🐶": 1}
+	if len(counts) != len(want) || counts["😀😃"] != 2 || counts["😀🐶"] != 1 {
+		t.Errorf("PrefixCollisions = %v, want %v", counts, want)
+	}
+
+	if counts := PrefixCollisions([]EmojiID{a, b, c}, 1); counts["😀"] != 3 {
+		t.Errorf("prefix 1: %v, want all 3 under 😀", counts)
+	}
+	if counts := PrefixCollisions([]EmojiID{a, c}, -5); counts[""] != 2 {
+		t.Errorf("negative prefix: %v, want both under the empty prefix", counts)
+	}
+	if counts := PrefixCollisions([]EmojiID{a, a, c}, 99); len(counts) != 2 || counts[string(a.Tokens())] != 2 {
+		t.Errorf("prefix above TokenCount: %v", counts)
+	}
+}
+
+func TestParseConcatenated(t *testing.T) {
+	ids := []EmojiID{MustNew(), MustNew(), MustNew()}
+	var b strings.Builder
+	for _, id := range ids {
+		b.WriteString(string(id.Tokens()))
+	}
+
+	got, err := ParseConcatenated(b.String(), len(ids), DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, ids) {
+		t.Errorf("ParseConcatenated = %v, want %v", got, ids)
+	}
+	if got, err := ParseConcatenated("", 0, DefaultAlphabet); err != nil || len(got) != 0 {
+		t.Errorf("empty stream: %v, %v", got, err)
+	}
+}
+
+func TestParseConcatenatedErrors(t *testing.T) {
+	stream := string(MustNew().Tokens()) + string(MustNew().Tokens())
+	for _, tc := range []struct {
+		name  string
+		in    string
+		count int
+		want  error
+	}{
+		{"count too small", stream, 1, ErrInvalidFormat},
+		{"count too large", stream, 3, ErrInvalidFormat},
+		{"negative count", stream, -2, ErrInvalidFormat},
+		// count*TokenCount wraps around to the stream length.
+		{"overflowing count", stream, 2 + 1<<(strconv.IntSize-5), ErrInvalidFormat},
+		{"partial ID", stream + "😀", 2, ErrInvalidFormat},
+		{"invalid UTF-8", stream[:len(stream)-1], 2, ErrInvalidUTF8},
+	} {
+		if _, err := ParseConcatenated(tc.in, tc.count, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	r := []rune(stream)
+	r[TokenCount+3] = '🕐'
+	_, err := ParseConcatenated(string(r), 2, DefaultAlphabet)
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) || tokenErr.Index != 3 || !strings.Contains(err.Error(), "id 1") {
+		t.Errorf("bad token: got %v, want *TokenError at index 3 of ID 1", err)
+	}
+}
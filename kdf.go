@@ -0,0 +1,67 @@
+package emojid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt cost parameters used by FromPassphrase: N=2^15, r=8, p=1, the
+// interactive-login recommendation. Each call needs 32 MiB of memory and takes in
+// the order of 50-100ms on current hardware, which is what makes guessing
+// passphrases expensive.
+const (
+	passphraseScryptN = 1 << 15
+	passphraseScryptR = 8
+	passphraseScryptP = 1
+)
+
+// FromPassphrase derives an EmojiID from a passphrase and salt with scrypt, so the
+// same inputs always reproduce the same ID, e.g. for offline recovery. Different
+// salts give unrelated IDs; use at least 16 random bytes of salt per user.
+//
+// The derived key feeds the same unbiased rejection sampling as New, so every
+// token is uniform over alphabet. This is deliberately slow (see the scrypt
+// parameters above) and is meant for recovery, not for generating IDs at volume.
+// The ID is only as unpredictable as the passphrase.
+func FromPassphrase(passphrase string, salt []byte, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, passphraseScryptN, passphraseScryptR, passphraseScryptP, 32)
+	if err != nil {
+		return EmojiID{}, err
+	}
+	return newWithSampler(alphabet, sampler{r: newKeyStream(key)})
+}
+
+// keyStream is a deterministic byte stream expanded from a key in counter mode:
+// block i is SHA-256(key || uint64(i)). It never runs out.
+type keyStream struct {
+	key     []byte
+	counter uint64
+	buf     []byte
+}
+
+func newKeyStream(key []byte) *keyStream {
+	return &keyStream{key: key}
+}
+
+func (s *keyStream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.buf) == 0 {
+			h := sha256.New()
+			h.Write(s.key)
+			h.Write(binary.BigEndian.AppendUint64(nil, s.counter))
+			s.buf = h.Sum(nil)
+			s.counter++
+		}
+		c := copy(p[n:], s.buf)
+		s.buf = s.buf[c:]
+		n += c
+	}
+	return n, nil
+}
@@ -0,0 +1,64 @@
+package emojid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFromPassphrase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("scrypt is slow")
+	}
+
+	salt := []byte("0123456789abcdef")
+	a, err := FromPassphrase("correct horse battery staple", salt, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := FromPassphrase("correct horse battery staple", salt, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("same inputs gave %v and %v", a, b)
+	}
+
+	c, err := FromPassphrase("correct horse battery staple", []byte("fedcba9876543210"), DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c == a {
+		t.Errorf("different salts gave the same ID %v", a)
+	}
+}
+
+func TestFromPassphraseAlphabetTooSmall(t *testing.T) {
+	if _, err := FromPassphrase("x", nil, []rune{'😀'}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestKeyStream(t *testing.T) {
+	// Reads of any size see the same stream.
+	whole := make([]byte, 100)
+	io.ReadFull(newKeyStream([]byte("k")), whole)
+
+	var pieces []byte
+	s := newKeyStream([]byte("k"))
+	for _, n := range []int{1, 31, 2, 33, 33} {
+		p := make([]byte, n)
+		io.ReadFull(s, p)
+		pieces = append(pieces, p...)
+	}
+	if !bytes.Equal(whole, pieces) {
+		t.Error("chunked reads differ from a single read")
+	}
+
+	other := make([]byte, 100)
+	io.ReadFull(newKeyStream([]byte("j")), other)
+	if bytes.Equal(whole, other) {
+		t.Error("different keys gave the same stream")
+	}
+}
@@ -0,0 +1,225 @@
+package emojid
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Layout describes how the TokenCount tokens of an EmojiID are split into
+// dash-separated groups, e.g. 8-4-4-4-12.
+type Layout []int
+
+// StandardLayout is the canonical UUID-like 8-4-4-4-12 grouping. It is the default
+// layout used by String and Parse unless changed with SetDefaultLayout.
+var StandardLayout = Layout{8, 4, 4, 4, 12}
+
+var defaultLayout atomic.Pointer[Layout]
+
+// SetDefaultLayout changes the layout used by String, Parse and the other
+// functions documented to use the default layout, for every goroutine. It is meant
+// to be called once at startup; IDs formatted before the change no longer parse
+// afterwards unless they are parsed with an explicit layout. The layout must pass
+// Validate.
+func SetDefaultLayout(l Layout) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+	l = append(Layout(nil), l...)
+	defaultLayout.Store(&l)
+	return nil
+}
+
+// DefaultLayout returns the layout set with SetDefaultLayout, or StandardLayout.
+// The returned layout must not be modified.
+func DefaultLayout() Layout {
+	if l := defaultLayout.Load(); l != nil {
+		return *l
+	}
+	return StandardLayout
+}
+
+// Validate reports whether every group is positive and the groups sum to TokenCount.
+func (l Layout) Validate() error {
+	if len(l) == 0 {
+		return ErrInvalidLayout
+	}
+
+	total := 0
+	for _, n := range l {
+		if n <= 0 {
+			return ErrInvalidLayout
+		}
+		total += n
+	}
+
+	if total != TokenCount {
+		return ErrInvalidLayout
+	}
+	return nil
+}
+
+// RecommendLayout returns the smallest single-group layout, i.e. token count,
+// whose IDs reach targetEntropyBits with an alphabet of alphabetSize entries:
+// ceil(target / log2(alphabetSize)) tokens, since each uniform token adds
+// log2(alphabetSize) bits. It is meant for sizing short IDs such as a prefix of
+// String; only a 32-token result is itself a valid EmojiID layout. A target that
+// needs more than TokenCount tokens returns ErrInsufficientEntropy.
+//
+// For reference, at 64, 96, 122 (UUIDv4) and 128 bits:
+//
+//	alphabet               bits/token  64  96  122  128
+//	DefaultAlphabet (152)  7.25         9  14   17   18
+//	CrossPlatform (134)    7.07        10  14   18   19
+//	64 entries             6.00        11  16   21   22
+//	16 entries             4.00        16  24   31   32
+//
+// Shorter IDs collide sooner: with b bits, expect the first collision after about
+// 2^(b/2) IDs.
+func RecommendLayout(targetEntropyBits float64, alphabetSize int) (Layout, error) {
+	if alphabetSize < 2 {
+		return nil, ErrAlphabetTooSmall
+	}
+
+	perToken := math.Log2(float64(alphabetSize))
+	n := max(1, int(math.Ceil(targetEntropyBits/perToken)))
+	// Guard against rounding up an exact multiple, e.g. 64 bits from 16 entries.
+	if n > 1 && float64(n-1)*perToken >= targetEntropyBits {
+		n--
+	}
+	if n > TokenCount {
+		return nil, fmt.Errorf("%w: %.1f bits needs %d tokens, more than %d", ErrInsufficientEntropy, targetEntropyBits, n, TokenCount)
+	}
+	return Layout{n}, nil
+}
+
+// FormatLayout formats the EmojiID with its tokens split into the groups of l.
+func (e EmojiID) FormatLayout(l Layout) (string, error) {
+	if err := l.Validate(); err != nil {
+		return "", err
+	}
+	return e.format(l, '-'), nil
+}
+
+// MatchesLayout reports whether the ID can be formatted in l: l must pass
+// Validate, i.e. its groups total the ID's 32 tokens. Nil matches no layout.
+func (e EmojiID) MatchesLayout(l Layout) bool {
+	return !e.IsZero() && l.Validate() == nil
+}
+
+// ValidateLayout reports whether s is an ID formatted in layout l, such as one
+// from FormatLayout, with every token in alphabet. It is the layout-aware
+// counterpart of Validate for environments that accept several layouts.
+func ValidateLayout(s string, l Layout, alphabet []rune) bool {
+	if len(alphabet) < 2 || l.Validate() != nil {
+		return false
+	}
+	allowed := cachedAlphabetSet(alphabet)
+	_, err := parseLayout(s, l, func(int) map[rune]struct{} { return allowed })
+	return err == nil
+}
+
+// Annotated returns a human-readable rendering that labels each group of the
+// default layout, e.g. "group1: 😀😃😄😁😆😅😂🤣 group2: 😊😇🙂🙃 ...". It is a debugging
+// aid and cannot be parsed; use String for the canonical form. Nil is rendered as
+// the empty string.
+func (e EmojiID) Annotated() string {
+	if e.IsZero() {
+		return ""
+	}
+
+	var b strings.Builder
+	for g, group := range e.Groups() {
+		if g > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "group%d: %s", g+1, string(group))
+	}
+	return b.String()
+}
+
+// NewWithPerGroupAlphabets returns a new random EmojiID where group i of the layout
+// draws its tokens from alphabets[i], e.g. faces for the first group and animals
+// for the rest. Each alphabet must contain at least 2 entries.
+func NewWithPerGroupAlphabets(layout Layout, alphabets [][]rune) (EmojiID, error) {
+	if err := checkPerGroupAlphabets(layout, alphabets); err != nil {
+		return EmojiID{}, err
+	}
+
+	var id EmojiID
+	for g, span := range layout.spans() {
+		alphabet := alphabets[g]
+		for i := span[0]; i < span[1]; i++ {
+			idx, err := cryptoRandIndex(len(alphabet))
+			if err != nil {
+				return EmojiID{}, err
+			}
+			id.tokens[i] = alphabet[idx]
+		}
+	}
+
+	return id, nil
+}
+
+// ParseWithPerGroupAlphabets parses an EmojiID formatted with layout and validates
+// that every token of group i is present in alphabets[i].
+func ParseWithPerGroupAlphabets(s string, layout Layout, alphabets [][]rune) (EmojiID, error) {
+	if err := checkPerGroupAlphabets(layout, alphabets); err != nil {
+		return EmojiID{}, err
+	}
+
+	sets := make([]map[rune]struct{}, len(alphabets))
+	for g, alphabet := range alphabets {
+		sets[g] = cachedAlphabetSet(alphabet)
+	}
+
+	return parseLayout(s, layout, func(g int) map[rune]struct{} { return sets[g] })
+}
+
+func checkPerGroupAlphabets(layout Layout, alphabets [][]rune) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	if len(alphabets) != len(layout) {
+		return fmt.Errorf("%w: %d alphabets for %d groups", ErrInvalidLayout, len(alphabets), len(layout))
+	}
+	for _, alphabet := range alphabets {
+		if len(alphabet) < 2 {
+			return ErrAlphabetTooSmall
+		}
+	}
+	return nil
+}
+
+// ParseColumns assembles an ID from the five groups of StandardLayout stored in
+// separate columns, as in spreadsheet and CSV exports, without dashes. Surrounding
+// whitespace in a column is ignored. Errors name the failing column (1-5); a
+// token not in alphabet is reported as a *TokenError with its position in the ID.
+func ParseColumns(cols [5]string, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+	allowed := cachedAlphabetSet(alphabet)
+
+	var id EmojiID
+	pos := 0
+	for c, col := range cols {
+		col = strings.TrimSpace(col)
+		if !utf8.ValidString(col) {
+			return EmojiID{}, fmt.Errorf("column %d: %w", c+1, ErrInvalidUTF8)
+		}
+		if n, want := utf8.RuneCountInString(col), StandardLayout[c]; n != want {
+			return EmojiID{}, fmt.Errorf("%w: column %d has %d tokens, want %d", ErrInvalidFormat, c+1, n, want)
+		}
+		for _, r := range col {
+			if _, ok := allowed[r]; !ok {
+				return EmojiID{}, fmt.Errorf("column %d: %w", c+1, &TokenError{Index: pos, Token: r})
+			}
+			id.tokens[pos] = r
+			pos++
+		}
+	}
+	return id, nil
+}
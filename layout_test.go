@@ -0,0 +1,254 @@
+package emojid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var (
+	testFaces   = []rune{'😀', '😃', '😄', '😁', '😆', '😅', '😂', '🤣'}
+	testAnimals = []rune{'🐶', '🐱', '🐭', '🐹', '🐰', '🦊', '🐻', '🐼'}
+)
+
+func TestPerGroupAlphabetsTwoAlphabets(t *testing.T) {
+	alphabets := [][]rune{testFaces, testAnimals, testAnimals, testAnimals, testAnimals}
+
+	id, err := NewWithPerGroupAlphabets(StandardLayout, alphabets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range id.Tokens() {
+		want := testAnimals
+		if i < StandardLayout[0] {
+			want = testFaces
+		}
+		if !slices.Contains(want, r) {
+			t.Errorf("token %d %q is not from its group's alphabet", i, string(r))
+		}
+	}
+
+	s := id.String()
+	got, err := ParseWithPerGroupAlphabets(s, StandardLayout, alphabets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("round trip = %v, want %v", got, id)
+	}
+
+	// An animal in the face group is rejected.
+	bad := string(testAnimals[0]) + strings.TrimPrefix(s, string(id.Tokens()[0]))
+	var te *TokenError
+	if _, err := ParseWithPerGroupAlphabets(bad, StandardLayout, alphabets); !errors.As(err, &te) || te.Index != 0 {
+		t.Errorf("animal in face group: got %v, want TokenError at index 0", err)
+	}
+}
+
+func TestPerGroupAlphabetsErrors(t *testing.T) {
+	if _, err := NewWithPerGroupAlphabets(StandardLayout, [][]rune{testFaces}); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("alphabet count mismatch: got %v, want ErrInvalidLayout", err)
+	}
+	if _, err := NewWithPerGroupAlphabets(Layout{16, 15}, [][]rune{testFaces, testAnimals}); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("short layout: got %v, want ErrInvalidLayout", err)
+	}
+	if _, err := NewWithPerGroupAlphabets(Layout{16, 16}, [][]rune{testFaces, {'x'}}); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("tiny alphabet: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+func TestLayoutValidate(t *testing.T) {
+	for _, l := range []Layout{StandardLayout, {32}, {16, 16}, {1, 31}} {
+		if err := l.Validate(); err != nil {
+			t.Errorf("%v: %v", l, err)
+		}
+	}
+	for _, l := range []Layout{nil, {}, {31}, {33}, {16, 0, 16}, {40, -8}} {
+		if err := l.Validate(); !errors.Is(err, ErrInvalidLayout) {
+			t.Errorf("%v: got %v, want ErrInvalidLayout", l, err)
+		}
+	}
+}
+
+func TestAnnotated(t *testing.T) {
+	id := MustNew()
+	got := id.Annotated()
+	tokens := id.Tokens()
+	i := 0
+	for g, n := range DefaultLayout() {
+		want := fmt.Sprintf("group%d: %s", g+1, string(tokens[i:i+n]))
+		if !strings.Contains(got, want) {
+			t.Errorf("Annotated() = %q, missing %q", got, want)
+		}
+		i += n
+	}
+	if Nil.Annotated() != "" {
+		t.Errorf("Nil.Annotated() = %q, want empty", Nil.Annotated())
+	}
+}
+
+func TestSetDefaultLayout(t *testing.T) {
+	t.Cleanup(func() { SetDefaultLayout(StandardLayout) })
+
+	id := MustNew()
+	standard := id.String()
+
+	custom := Layout{4, 4, 4, 4, 4, 4, 4, 4}
+	if err := SetDefaultLayout(custom); err != nil {
+		t.Fatal(err)
+	}
+	custom[0] = 99 // the stored layout is a copy
+	if got := DefaultLayout(); got[0] != 4 {
+		t.Fatalf("DefaultLayout changed with the caller's slice: %v", got)
+	}
+
+	s := id.String()
+	if strings.Count(s, "-") != 7 {
+		t.Errorf("String() = %q, want 8 groups", s)
+	}
+	got, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("round trip = %v, want %v", got, id)
+	}
+	if _, err := Parse(standard); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("standard form under custom layout: got %v, want ErrInvalidFormat", err)
+	}
+
+	if err := SetDefaultLayout(Layout{16, 15}); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("invalid layout: got %v, want ErrInvalidLayout", err)
+	}
+	if got := DefaultLayout(); len(got) != 8 {
+		t.Errorf("invalid layout replaced the default: %v", got)
+	}
+}
+
+func TestRecommendLayout(t *testing.T) {
+	for _, tc := range []struct {
+		bits float64
+		size int
+		want int
+	}{
+		{128, len(DefaultAlphabet), 18},
+		{128, len(CrossPlatformAlphabet), 19},
+		{122, len(DefaultAlphabet), 17},
+		{64, 16, 16}, // exact multiple
+		{128, 16, 32},
+		{128, 64, 22},
+		{0, 16, 1},
+	} {
+		l, err := RecommendLayout(tc.bits, tc.size)
+		if err != nil {
+			t.Errorf("RecommendLayout(%v, %d): %v", tc.bits, tc.size, err)
+			continue
+		}
+		if len(l) != 1 || l[0] != tc.want {
+			t.Errorf("RecommendLayout(%v, %d) = %v, want [%d]", tc.bits, tc.size, l, tc.want)
+		}
+		// Minimal: one token fewer misses the target.
+		if n := float64(l[0] - 1); l[0] > 1 && n*EntropyBits(tc.size)/TokenCount >= tc.bits {
+			t.Errorf("RecommendLayout(%v, %d) = %v is not minimal", tc.bits, tc.size, l)
+		}
+	}
+}
+
+func TestRecommendLayoutErrors(t *testing.T) {
+	if _, err := RecommendLayout(129, 16); !errors.Is(err, ErrInsufficientEntropy) {
+		t.Errorf("129 bits from 16 entries: got %v, want ErrInsufficientEntropy", err)
+	}
+	if _, err := RecommendLayout(64, 1); !errors.Is(err, ErrAlphabetTooSmall) {
+		t.Errorf("1 entry: got %v, want ErrAlphabetTooSmall", err)
+	}
+}
+
+// columns splits id into the five StandardLayout groups.
+func columns(id EmojiID) [5]string {
+	var cols [5]string
+	i := 0
+	for c, n := range StandardLayout {
+		cols[c] = string(id.tokens[i : i+n])
+		i += n
+	}
+	return cols
+}
+
+func TestParseColumns(t *testing.T) {
+	id := MustNew()
+	cols := columns(id)
+	cols[1] = " " + cols[1] + "\t"
+	got, err := ParseColumns(cols, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("ParseColumns = %v, want %v", got, id)
+	}
+}
+
+func TestParseColumnsErrors(t *testing.T) {
+	id := MustNew()
+
+	short := columns(id)
+	short[2] = string([]rune(short[2])[:3])
+	if _, err := ParseColumns(short, DefaultAlphabet); !errors.Is(err, ErrInvalidFormat) || !strings.Contains(err.Error(), "column 3") {
+		t.Errorf("short column: got %v, want ErrInvalidFormat naming column 3", err)
+	}
+
+	foreign := columns(id)
+	foreign[4] = "🕐" + string([]rune(foreign[4])[1:])
+	_, err := ParseColumns(foreign, DefaultAlphabet)
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) || tokenErr.Index != 20 || !strings.Contains(err.Error(), "column 5") {
+		t.Errorf("foreign token: got %v, want *TokenError at index 20 in column 5", err)
+	}
+
+	invalid := columns(id)
+	invalid[0] = "\xff"
+	if _, err := ParseColumns(invalid, DefaultAlphabet); !errors.Is(err, ErrInvalidUTF8) {
+		t.Errorf("invalid UTF-8: got %v, want ErrInvalidUTF8", err)
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	id := MustNew()
+	l := Layout{4, 4, 4, 4, 16}
+	s, err := id.FormatLayout(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ValidateLayout(s, l, DefaultAlphabet) {
+		t.Errorf("ValidateLayout(%q, %v) = false", s, l)
+	}
+	if ValidateLayout(s, StandardLayout, DefaultAlphabet) {
+		t.Error("ID validates under a different layout")
+	}
+	if !ValidateLayout(id.String(), StandardLayout, DefaultAlphabet) {
+		t.Error("String form does not validate under StandardLayout")
+	}
+	if ValidateLayout(s, l, testFaces) || ValidateLayout(s, l, []rune{'😀'}) {
+		t.Error("ID validates with a foreign alphabet")
+	}
+	if ValidateLayout(s, Layout{4, 4, 4, 4, 15}, DefaultAlphabet) {
+		t.Error("ID validates under an invalid layout")
+	}
+}
+
+func TestMatchesLayout(t *testing.T) {
+	id := MustNew()
+	if !id.MatchesLayout(StandardLayout) || !id.MatchesLayout(Layout{TokenCount}) {
+		t.Error("ID does not match valid layouts")
+	}
+	if id.MatchesLayout(Layout{8, 4}) || id.MatchesLayout(nil) {
+		t.Error("ID matches invalid layouts")
+	}
+	if Nil.MatchesLayout(StandardLayout) {
+		t.Error("Nil matches a layout")
+	}
+	if _, err := id.FormatLayout(Layout{16, 15}); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("FormatLayout with an invalid layout: got %v, want ErrInvalidLayout", err)
+	}
+}
@@ -0,0 +1,62 @@
+package emojid
+
+import "strings"
+
+// LegacySafeUnicodeVersion is the cutoff for LegacySafeAlphabet: only emoji whose
+// code point was assigned in this Unicode version or earlier are included.
+const LegacySafeUnicodeVersion = "6.0"
+
+// unicodeAdded lists the Unicode version that assigned each DefaultAlphabet
+// emoji, in ascending version order. It must cover every DefaultAlphabet entry.
+var unicodeAdded = []struct {
+	version string
+	runes   string
+}{
+	{"1.1", "✈❄"},
+	{"4.0", "☕⚡"},
+	{"4.1", "⚙"},
+	{"5.1", "⭐"},
+	{"5.2", "⚽⚾⛵"},
+	{"6.0", "😃😄😁😆😅😂😊😇😉😌😍😘😚😋😝😜😎😤😡😱😷😈👻🎃" +
+		"🐶🐱🐭🐹🐰🐻🐼🐨🐯🐸🐵🐔🐧🐦🐤🐙🐠🐳🐞🌸🌼🌻🌺" +
+		"🍎🍊🍋🍉🍇🍓🍒🍍🍔🍟🍕🍣🍩🍪🍫🍺🍷🏀🏈🎾🎱🎸🎹🎻🎧🎮🎲" +
+		"🚗🚕🚌🚑🚒🚜🚀🚲🏠🏢🏭🏰🌍🌙🔥💧🌈💎🔒🔑💡📦🔭📡💾"},
+	{"6.1", "😀😗😙😛😴"},
+	{"7.0", "🙂🌶🛰🛡🗄"},
+	{"8.0", "🙃🤓🤒🤕🤖🦁🦀🌮🍿🏐🏓"},
+	{"9.0", "🤣🤤🤠🦊🦑🦋🥑🥕🥁🛴"},
+	{"10.0", "🤪🤨🧐🤯🥦🧠"},
+	{"11.0", "🥰🥳🧩🧲🧰🧪🧬"},
+}
+
+// LegacySafeAlphabet is the subset of DefaultAlphabet assigned in Unicode 6.0
+// (2010) or earlier, which the emoji fonts of practically every device since
+// about 2012 cover, for IDs that must render on old devices instead of showing
+// tofu boxes. The cutoff is LegacySafeUnicodeVersion; newer emoji such as 🥰 🥳 🧐
+// 🤯 🦊 are left out. The symbols ✈ ❄ ⚙ predate emoji and may render as
+// monochrome text glyphs.
+//
+// It has 108 entries, about 216 bits per ID.
+var LegacySafeAlphabet = func() []rune {
+	var older strings.Builder
+	for _, e := range unicodeAdded {
+		older.WriteString(e.runes)
+		if e.version == LegacySafeUnicodeVersion {
+			break
+		}
+	}
+	allowed := alphabetSet([]rune(older.String()))
+
+	var alphabet []rune
+	for _, r := range DefaultAlphabet {
+		if _, ok := allowed[r]; ok {
+			alphabet = append(alphabet, r)
+		}
+	}
+	return alphabet
+}()
+
+// NewLegacySafe returns a new random EmojiID using LegacySafeAlphabet.
+func NewLegacySafe() (EmojiID, error) {
+	return NewWithAlphabet(LegacySafeAlphabet)
+}
@@ -0,0 +1,78 @@
+package emojid
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// parseVersion splits a "major.minor" Unicode version.
+func parseVersion(t *testing.T, v string) [2]int {
+	t.Helper()
+	major, minor, ok := strings.Cut(v, ".")
+	a, errA := strconv.Atoi(major)
+	b, errB := strconv.Atoi(minor)
+	if !ok || errA != nil || errB != nil {
+		t.Fatalf("malformed version %q", v)
+	}
+	return [2]int{a, b}
+}
+
+func TestUnicodeAddedTable(t *testing.T) {
+	seen := make(map[rune]string)
+	var prev [2]int
+	for _, e := range unicodeAdded {
+		v := parseVersion(t, e.version)
+		if slices.Compare(v[:], prev[:]) <= 0 {
+			t.Errorf("version %s is not after the previous one", e.version)
+		}
+		prev = v
+		for _, r := range e.runes {
+			if other, dup := seen[r]; dup {
+				t.Errorf("%q listed under %s and %s", string(r), other, e.version)
+			}
+			seen[r] = e.version
+		}
+	}
+	for _, r := range DefaultAlphabet {
+		if _, ok := seen[r]; !ok {
+			t.Errorf("%q has no Unicode version", string(r))
+		}
+	}
+	if len(seen) != len(DefaultAlphabet) {
+		t.Errorf("table lists %d emoji, DefaultAlphabet has %d", len(seen), len(DefaultAlphabet))
+	}
+}
+
+func TestLegacySafeAlphabet(t *testing.T) {
+	if got := len(LegacySafeAlphabet); got != 108 {
+		t.Errorf("len = %d, want 108", got)
+	}
+	if err := ValidateAlphabet(LegacySafeAlphabet); err != nil {
+		t.Fatal(err)
+	}
+
+	cutoff := parseVersion(t, LegacySafeUnicodeVersion)
+	added := make(map[rune][2]int)
+	for _, e := range unicodeAdded {
+		for _, r := range e.runes {
+			added[r] = parseVersion(t, e.version)
+		}
+	}
+	for _, r := range DefaultAlphabet {
+		v := added[r]
+		old := slices.Compare(v[:], cutoff[:]) <= 0
+		if in := slices.Contains(LegacySafeAlphabet, r); in != old {
+			t.Errorf("%q (Unicode %d.%d): in LegacySafeAlphabet = %v", string(r), v[0], v[1], in)
+		}
+	}
+
+	id, err := NewLegacySafe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseWithAlphabet(id.String(), LegacySafeAlphabet); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,98 @@
+package emojid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mnemonicWords maps every DefaultAlphabet emoji to a distinct, single, lower-case
+// English word that is easy to say and hear, in alphabet order. No word is a
+// prefix of another, so a listener cannot stop too early on "car" for "carrot".
+var mnemonicWords = map[rune]string{
+	'😀': "grinning", '😃': "cheerful", '😄': "smile", '😁': "beaming",
+	'😆': "laughing", '😅': "sweat", '😂': "joy", '🤣': "rolling",
+	'😊': "blush", '😇': "halo", '🙂': "pleased", '🙃': "upside",
+	'😉': "wink", '😌': "relieved", '😍': "adore", '🥰': "loving",
+	'😘': "smooch", '😗': "kissing", '😙': "whistle", '😚': "peck",
+	'😋': "yum", '😛': "tongue", '😝': "squint", '😜': "cheeky",
+	'🤪': "zany", '🤨': "skeptic", '🧐': "monocle", '🤓': "nerd",
+	'😎': "cool", '🥳': "party", '😤': "huff", '😡': "rage",
+	'🤯': "exploding", '😱': "scream", '😴': "sleeping", '🤤': "drool",
+	'😷': "mask", '🤒': "fever", '🤕': "bandage", '🤠': "cowboy",
+	'😈': "imp", '👻': "ghost", '🤖': "robot", '🎃': "pumpkin",
+	'🐶': "dog", '🐱': "cat", '🐭': "mouse", '🐹': "hamster",
+	'🐰': "rabbit", '🦊': "fox", '🐻': "bear", '🐼': "panda",
+	'🐨': "koala", '🐯': "tiger", '🦁': "lion", '🐸': "frog",
+	'🐵': "monkey", '🐔': "chicken", '🐧': "penguin", '🐦': "bird",
+	'🐤': "hatchling", '🐙': "octopus", '🦑': "squid", '🦀': "crab",
+	'🐠': "fish", '🐳': "whale", '🦋': "butterfly", '🐞': "ladybug",
+	'🌸': "blossom", '🌼': "daisy", '🌻': "sunflower", '🌺': "hibiscus",
+	'🍎': "apple", '🍊': "tangerine", '🍋': "lemon", '🍉': "watermelon",
+	'🍇': "grapes", '🍓': "strawberry", '🍒': "cherries", '🍍': "pineapple",
+	'🥑': "avocado", '🥦': "broccoli", '🥕': "carrot", '🌶': "pepper",
+	'🍔': "burger", '🍟': "fries", '🍕': "pizza", '🌮': "taco",
+	'🍣': "sushi", '🍩': "donut", '🍪': "cookie", '🍫': "chocolate",
+	'🍿': "popcorn", '☕': "coffee", '🍺': "beer", '🍷': "wine",
+	'⚽': "soccer", '🏀': "basketball", '🏈': "football", '⚾': "baseball",
+	'🎾': "tennis", '🏐': "volleyball", '🎱': "billiards", '🏓': "pingpong",
+	'🎸': "guitar", '🎹': "piano", '🥁': "drum", '🎻': "violin",
+	'🎧': "headphones", '🎮': "controller", '🧩': "jigsaw", '🎲': "dice",
+	'🚗': "automobile", '🚕': "taxi", '🚌': "bus", '🚑': "ambulance",
+	'🚒': "firetruck", '🚜': "tractor", '✈': "airplane", '🚀': "rocket",
+	'🛰': "orbiter", '⛵': "sailboat", '🚲': "bicycle", '🛴': "scooter",
+	'🏠': "house", '🏢': "office", '🏭': "factory", '🏰': "castle",
+	'🌍': "earth", '🌙': "moon", '⭐': "star", '⚡': "lightning",
+	'🔥': "flame", '💧': "droplet", '🌈': "rainbow", '❄': "snowflake",
+	'💎': "gem", '🔒': "lock", '🔑': "key", '🧠': "brain",
+	'💡': "bulb", '📦': "parcel", '🧲': "magnet", '🧰': "toolbox",
+	'🛡': "shield", '⚙': "gear", '🧪': "flask", '🧬': "helix",
+	'🔭': "telescope", '📡': "antenna", '💾': "floppy", '🗄': "cabinet",
+}
+
+// mnemonicRunes is the reverse of mnemonicWords.
+var mnemonicRunes = func() map[string]rune {
+	m := make(map[string]rune, len(mnemonicWords))
+	for r, word := range mnemonicWords {
+		m[word] = r
+	}
+	return m
+}()
+
+// Mnemonic returns one English word per token, e.g. ["grinning", "dog", ...], for
+// reading an ID aloud over the phone. FromMnemonic reverses it. Tokens outside
+// DefaultAlphabet have no word and are returned as the emoji itself, which
+// FromMnemonic does not accept. Nil yields nil.
+func (e EmojiID) Mnemonic() []string {
+	if e.IsZero() {
+		return nil
+	}
+
+	words := make([]string, TokenCount)
+	for i, r := range e.tokens {
+		if word, ok := mnemonicWords[r]; ok {
+			words[i] = word
+		} else {
+			words[i] = string(r)
+		}
+	}
+	return words
+}
+
+// FromMnemonic parses the TokenCount words produced by Mnemonic. Matching ignores
+// case and surrounding whitespace. An unknown word is reported as
+// ErrInvalidToken with its position.
+func FromMnemonic(words []string) (EmojiID, error) {
+	if len(words) != TokenCount {
+		return EmojiID{}, fmt.Errorf("%w: got %d words, want %d", ErrInvalidFormat, len(words), TokenCount)
+	}
+
+	var id EmojiID
+	for i, word := range words {
+		r, ok := mnemonicRunes[strings.ToLower(strings.TrimSpace(word))]
+		if !ok {
+			return EmojiID{}, fmt.Errorf("%w: unknown word %q at index %d", ErrInvalidToken, word, i)
+		}
+		id.tokens[i] = r
+	}
+	return id, nil
+}
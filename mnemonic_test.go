@@ -0,0 +1,64 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMnemonicWordsCoverDefaultAlphabet(t *testing.T) {
+	if len(mnemonicWords) != len(DefaultAlphabet) {
+		t.Fatalf("got %d words, want %d", len(mnemonicWords), len(DefaultAlphabet))
+	}
+	for _, r := range DefaultAlphabet {
+		if _, ok := mnemonicWords[r]; !ok {
+			t.Errorf("no word for %q", string(r))
+		}
+	}
+	if len(mnemonicRunes) != len(mnemonicWords) {
+		t.Errorf("words are not distinct: %d words map back to %d runes", len(mnemonicWords), len(mnemonicRunes))
+	}
+}
+
+func TestMnemonicWordsPrefixFree(t *testing.T) {
+	for _, a := range mnemonicWords {
+		if a != strings.ToLower(a) || strings.ContainsAny(a, " -") {
+			t.Errorf("word %q is not a single lower-case word", a)
+		}
+		for _, b := range mnemonicWords {
+			if a != b && strings.HasPrefix(b, a) {
+				t.Errorf("word %q is a prefix of %q", a, b)
+			}
+		}
+	}
+}
+
+func TestMnemonicRoundTrip(t *testing.T) {
+	id := MustNew()
+	words := id.Mnemonic()
+	if len(words) != TokenCount {
+		t.Fatalf("got %d words, want %d", len(words), TokenCount)
+	}
+	words[0] = "  " + strings.ToUpper(words[0]) + " "
+	got, err := FromMnemonic(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("FromMnemonic = %v, want %v", got, id)
+	}
+	if Nil.Mnemonic() != nil {
+		t.Error("Nil.Mnemonic() is not nil")
+	}
+}
+
+func TestFromMnemonicErrors(t *testing.T) {
+	if _, err := FromMnemonic([]string{"dog"}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("short list: got %v, want ErrInvalidFormat", err)
+	}
+	words := MustNew().Mnemonic()
+	words[5] = "xylophone"
+	if _, err := FromMnemonic(words); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("unknown word: got %v, want ErrInvalidToken", err)
+	}
+}
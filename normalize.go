@@ -0,0 +1,49 @@
+package emojid
+
+import "strings"
+
+// Normalize removes the presentation selectors U+FE0E (text style) and U+FE0F
+// (emoji style) from s. Keyboards and copy-paste often append U+FE0F to symbols
+// such as ☕ or ❄, which Parse would otherwise count as an extra token.
+func Normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isPresentationSelector(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func isPresentationSelector(r rune) bool {
+	return r == '\uFE0E' || r == '\uFE0F'
+}
+
+// EqualNormalized reports whether e and other are equal once presentation
+// selectors are ignored on both sides.
+//
+// Use Equal to compare IDs exactly as stored. Use EqualNormalized when IDs may have
+// been built from alphabets that contain selector code points, so that a selector
+// token does not make otherwise identical IDs differ. For alphabets without
+// selectors, such as DefaultAlphabet, the two are equivalent.
+func (e EmojiID) EqualNormalized(other EmojiID) bool {
+	return Normalize(e.String()) == Normalize(other.String())
+}
+
+// ParseTolerant is like ParseWithAlphabet but first trims surrounding whitespace
+// and applies Normalize, for IDs typed or pasted by users.
+func ParseTolerant(s string, alphabet []rune) (EmojiID, error) {
+	id, _, err := ParseReport(s, alphabet)
+	return id, err
+}
+
+// ParseReport is like ParseTolerant and also reports whether the input had to be
+// changed to parse, i.e. whether it was not already in canonical form. Callers can
+// use it to flag stored values worth rewriting with String.
+func ParseReport(s string, alphabet []rune) (EmojiID, bool, error) {
+	n := Normalize(strings.TrimSpace(s))
+	id, err := ParseWithAlphabet(n, alphabet)
+	if err != nil {
+		return EmojiID{}, false, err
+	}
+	return id, n != s, nil
+}
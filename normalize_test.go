@@ -0,0 +1,76 @@
+package emojid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualNormalized(t *testing.T) {
+	// An alphabet that contains the selector itself, so two IDs can differ only in
+	// where the selector sits.
+	alphabet := []rune{'☕', '\uFE0F'}
+	a, b := make([]int, TokenCount), make([]int, TokenCount)
+	a[1], b[2] = 1, 1
+
+	x, err := FromIndices(a, alphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := FromIndices(b, alphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if x.Equal(y) {
+		t.Error("Equal reports true for IDs with selectors at different positions")
+	}
+	if !x.EqualNormalized(y) {
+		t.Error("EqualNormalized reports false for IDs that differ only by U+FE0F")
+	}
+	if MustNew().EqualNormalized(MustNew()) {
+		t.Error("EqualNormalized reports true for two random IDs")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("☕\uFE0F-❄\uFE0E"); got != "☕-❄" {
+		t.Errorf("Normalize = %q, want %q", got, "☕-❄")
+	}
+	s := MustNewString()
+	if Normalize(s) != s {
+		t.Error("Normalize changed a canonical ID")
+	}
+	if strings.ContainsAny(Normalize("\uFE0F\uFE0E"), "\uFE0F\uFE0E") {
+		t.Error("Normalize left a selector")
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	id := MustNew()
+	s := id.String()
+	for _, tc := range []struct {
+		in      string
+		changed bool
+	}{
+		{s, false},
+		{"  " + s + "\n", true},
+		{strings.Replace(s, "-", "\uFE0F-", 1), true},
+		{"\t" + strings.ReplaceAll(s, "-", "\uFE0E-") + " ", true},
+	} {
+		got, changed, err := ParseReport(tc.in, DefaultAlphabet)
+		if err != nil {
+			t.Errorf("ParseReport(%q): %v", tc.in, err)
+			continue
+		}
+		if got != id || changed != tc.changed {
+			t.Errorf("ParseReport(%q) = %v, %v; want %v, %v", tc.in, got, changed, id, tc.changed)
+		}
+		if got, err := ParseTolerant(tc.in, DefaultAlphabet); err != nil || got != id {
+			t.Errorf("ParseTolerant(%q) = %v, %v", tc.in, got, err)
+		}
+	}
+
+	if _, changed, err := ParseReport(" "+s[:len(s)-4], DefaultAlphabet); err == nil || changed {
+		t.Errorf("truncated input: changed %v, err %v; want false and an error", changed, err)
+	}
+}
@@ -0,0 +1,70 @@
+package emojid
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ParseStats counts the outcomes of ParseWithStats by failure category, to show
+// the shape of bad input at scale.
+//
+// A ParseStats is safe for concurrent use.
+type ParseStats struct {
+	ok            atomic.Int64
+	invalidUTF8   atomic.Int64
+	badGroupCount atomic.Int64
+	badLength     atomic.Int64
+	invalidToken  atomic.Int64
+	other         atomic.Int64
+}
+
+// ParseCounts is a snapshot of a ParseStats.
+type ParseCounts struct {
+	OK            int // parsed successfully
+	InvalidUTF8   int // not valid UTF-8
+	BadGroupCount int // wrong number of dash-separated groups
+	BadLength     int // right number of groups, but a group has the wrong size
+	InvalidToken  int // a token is not in the alphabet
+	Other         int // anything else, e.g. an alphabet that is too small
+}
+
+// Stats returns the counts recorded so far.
+func (s *ParseStats) Stats() ParseCounts {
+	return ParseCounts{
+		OK:            int(s.ok.Load()),
+		InvalidUTF8:   int(s.invalidUTF8.Load()),
+		BadGroupCount: int(s.badGroupCount.Load()),
+		BadLength:     int(s.badLength.Load()),
+		InvalidToken:  int(s.invalidToken.Load()),
+		Other:         int(s.other.Load()),
+	}
+}
+
+// ParseWithStats is like ParseWithAlphabet and also counts the outcome in stats,
+// which may be nil. Classifying a failure only costs extra work on the failure
+// path.
+func ParseWithStats(s string, alphabet []rune, stats *ParseStats) (EmojiID, error) {
+	id, err := ParseWithAlphabet(s, alphabet)
+	if stats == nil {
+		return id, err
+	}
+
+	var tokenErr *TokenError
+	switch {
+	case err == nil:
+		stats.ok.Add(1)
+	case errors.Is(err, ErrInvalidUTF8):
+		stats.invalidUTF8.Add(1)
+	case errors.As(err, &tokenErr):
+		stats.invalidToken.Add(1)
+	case errors.Is(err, ErrInvalidFormat):
+		if len(splitGroups(s, isDash)) != len(DefaultLayout()) {
+			stats.badGroupCount.Add(1)
+		} else {
+			stats.badLength.Add(1)
+		}
+	default:
+		stats.other.Add(1)
+	}
+	return id, err
+}
@@ -0,0 +1,54 @@
+package emojid
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseWithStats(t *testing.T) {
+	s := MustNew().String()
+	inputs := []string{
+		s, s, // OK
+		"\xff" + s,                     // InvalidUTF8
+		strings.Replace(s, "-", "", 1), // BadGroupCount
+		s + "-😀",                       // BadGroupCount
+		string([]rune(s)[1:]),          // BadLength
+		"🕐" + string([]rune(s)[1:]),    // InvalidToken
+	}
+
+	var stats ParseStats
+	for _, in := range inputs {
+		ParseWithStats(in, DefaultAlphabet, &stats)
+	}
+	ParseWithStats(s, []rune{'😀'}, &stats) // Other
+
+	want := ParseCounts{OK: 2, InvalidUTF8: 1, BadGroupCount: 2, BadLength: 1, InvalidToken: 1, Other: 1}
+	if got := stats.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	if id, err := ParseWithStats(s, DefaultAlphabet, nil); err != nil || id.String() != s {
+		t.Errorf("nil stats: %v, %v", id, err)
+	}
+}
+
+func TestParseWithStatsConcurrent(t *testing.T) {
+	s := MustNew().String()
+	var stats ParseStats
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				ParseWithStats(s, DefaultAlphabet, &stats)
+				ParseWithStats("", DefaultAlphabet, &stats)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := stats.Stats(); got.OK != 800 || got.BadGroupCount+got.BadLength != 800 {
+		t.Errorf("Stats() = %+v, want 800 OK and 800 format errors", got)
+	}
+}
@@ -0,0 +1,65 @@
+package emojid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// QRPayload encodes the EmojiID as a fixed-width string of decimal digits for QR
+// codes, where emoji would force the bulky byte mode.
+//
+// The token indices are read as one base-len(alphabet) number and written in
+// decimal, zero-padded to the width of the largest ID. Digits let the QR encoder
+// use numeric mode, which packs 3 digits into 10 bits. For DefaultAlphabet that
+// is 70 digits in 234 bits, against 237 bits for the best alphanumeric-mode
+// encoding (base 45) and 286 bits for Crockford base32 in alphanumeric mode. Use
+// ToBase32 for general-purpose ASCII; use this only when the target is a QR code.
+func (e EmojiID) QRPayload(alphabet []rune) (string, error) {
+	indices, err := e.Indices(alphabet)
+	if err != nil {
+		return "", err
+	}
+
+	base := big.NewInt(int64(len(alphabet)))
+	n := new(big.Int)
+	for _, idx := range indices {
+		n.Mul(n, base).Add(n, big.NewInt(int64(idx)))
+	}
+
+	digits := n.String()
+	return strings.Repeat("0", qrPayloadWidth(len(alphabet))-len(digits)) + digits, nil
+}
+
+// FromQRPayload decodes a payload produced by QRPayload with the same alphabet.
+func FromQRPayload(s string, alphabet []rune) (EmojiID, error) {
+	if len(alphabet) < 2 {
+		return EmojiID{}, ErrAlphabetTooSmall
+	}
+	if len(s) != qrPayloadWidth(len(alphabet)) || strings.Trim(s, "0123456789") != "" {
+		return EmojiID{}, ErrInvalidFormat
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return EmojiID{}, ErrInvalidFormat
+	}
+
+	base := big.NewInt(int64(len(alphabet)))
+	indices := make([]int, TokenCount)
+	digit := new(big.Int)
+	for i := TokenCount - 1; i >= 0; i-- {
+		n.DivMod(n, base, digit)
+		indices[i] = int(digit.Int64())
+	}
+	if n.Sign() != 0 {
+		return EmojiID{}, fmt.Errorf("%w: payload out of range", ErrInvalidFormat)
+	}
+	return FromIndices(indices, alphabet)
+}
+
+// qrPayloadWidth returns the number of decimal digits of alphabetSize^32 - 1.
+func qrPayloadWidth(alphabetSize int) int {
+	n := new(big.Int).Exp(big.NewInt(int64(alphabetSize)), big.NewInt(TokenCount), nil)
+	return len(n.Sub(n, big.NewInt(1)).String())
+}
@@ -0,0 +1,51 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQRPayloadRoundTrip(t *testing.T) {
+	for _, alphabet := range [][]rune{DefaultAlphabet, testFaces} {
+		width := qrPayloadWidth(len(alphabet))
+		for range 20 {
+			id, err := NewWithAlphabet(alphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := id.QRPayload(alphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p) != width || strings.Trim(p, "0123456789") != "" {
+				t.Fatalf("payload %q is not %d digits", p, width)
+			}
+			got, err := FromQRPayload(p, alphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != id {
+				t.Fatalf("round trip = %v, want %v", got, id)
+			}
+		}
+	}
+	if got := qrPayloadWidth(len(DefaultAlphabet)); got != 70 {
+		t.Errorf("DefaultAlphabet width = %d, want 70", got)
+	}
+}
+
+func TestFromQRPayloadErrors(t *testing.T) {
+	width := qrPayloadWidth(len(testFaces))
+	for _, s := range []string{
+		"",
+		strings.Repeat("0", width-1),
+		strings.Repeat("0", width+1),
+		strings.Repeat("0", width-1) + "x",
+		strings.Repeat("9", width), // 10^width-1 exceeds 8^32-1
+	} {
+		if _, err := FromQRPayload(s, testFaces); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("FromQRPayload(%q): got %v, want ErrInvalidFormat", s, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package emojid
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string][]rune)
+	layouts    = make(map[string]Layout)
+)
+
+// RegisterAlphabet makes a copy of alphabet available under name for
+// LookupAlphabet and NewForLocale. The alphabet must pass ValidateAlphabet.
+// Registering an existing name replaces the previous alphabet.
+func RegisterAlphabet(name string, alphabet []rune) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidAlphabet)
+	}
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return err
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = append([]rune(nil), alphabet...)
+	return nil
+}
+
+// LookupAlphabet returns a copy of the alphabet registered under name.
+func LookupAlphabet(name string) ([]rune, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	alphabet, ok := registry[name]
+	if !ok {
+		return nil, false
+	}
+	return append([]rune(nil), alphabet...), true
+}
+
+// NewForLocale returns a new random EmojiID from the registered alphabet that best
+// matches locale, a tag such as "ja-JP" or "en_US". For language ll and region rr
+// it tries "default.ll-rr", then "default.rr", then "default.ll" (all lower case),
+// and falls back to the default alphabet. No locale alphabets are registered by default;
+// deployments register their own subsets with RegisterAlphabet.
+func NewForLocale(locale string) (EmojiID, error) {
+	for _, name := range localeCandidates(locale) {
+		if alphabet, ok := LookupAlphabet(name); ok {
+			return NewWithAlphabet(alphabet)
+		}
+	}
+	return New()
+}
+
+// localeCandidates returns the registry names to try for locale, best first.
+func localeCandidates(locale string) []string {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		return nil
+	}
+
+	lang, region, _ := strings.Cut(locale, "-")
+	// Drop any script or variant subtags, e.g. "zh-hant-tw" -> region "tw".
+	if i := strings.LastIndexByte(region, '-'); i >= 0 {
+		region = region[i+1:]
+	}
+
+	if region == "" {
+		return []string{"default." + lang}
+	}
+	return []string{
+		"default." + lang + "-" + region,
+		"default." + region,
+		"default." + lang,
+	}
+}
+
+// RegisterLayout makes a copy of l available under name for LookupLayout and
+// ValidateAnyLayout. The layout must pass Validate. Registering an existing name
+// replaces the previous layout.
+func RegisterLayout(name string, l Layout) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidLayout)
+	}
+	if err := l.Validate(); err != nil {
+		return err
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	layouts[name] = append(Layout(nil), l...)
+	return nil
+}
+
+// LookupLayout returns a copy of the layout registered under name.
+func LookupLayout(name string) (Layout, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	l, ok := layouts[name]
+	if !ok {
+		return nil, false
+	}
+	return append(Layout(nil), l...), true
+}
+
+// ValidateAnyLayout is like Validate with an explicit alphabet but accepts s in
+// the default layout or in any layout registered with RegisterLayout. The default
+// layout is tried first, then the registered ones in name order; the result only
+// depends on whether some layout matches, not on which.
+func ValidateAnyLayout(s string, alphabet []rune) bool {
+	if ValidateLayout(s, DefaultLayout(), alphabet) {
+		return true
+	}
+
+	registryMu.RLock()
+	names := slices.Sorted(maps.Keys(layouts))
+	candidates := make([]Layout, len(names))
+	for i, name := range names {
+		candidates[i] = layouts[name]
+	}
+	registryMu.RUnlock()
+
+	for _, l := range candidates {
+		if ValidateLayout(s, l, alphabet) {
+			return true
+		}
+	}
+	return false
+}
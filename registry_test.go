@@ -0,0 +1,138 @@
+package emojid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// registerForTest registers alphabet under name and removes it when t ends.
+func registerForTest(t *testing.T, name string, alphabet []rune) {
+	t.Helper()
+	if err := RegisterAlphabet(name, alphabet); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, name)
+	})
+}
+
+func TestNewForLocaleExactMatch(t *testing.T) {
+	registerForTest(t, "default.ja-jp", testFaces)
+	registerForTest(t, "default.ja", testAnimals)
+
+	id, err := NewForLocale("ja_JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range id.Tokens() {
+		if !slices.Contains(testFaces, r) {
+			t.Fatalf("token %q is not from the ja-jp alphabet", string(r))
+		}
+	}
+}
+
+func TestNewForLocaleFallback(t *testing.T) {
+	registerForTest(t, "default.ja", testAnimals)
+
+	// ja-JP falls back to the language alphabet.
+	id, err := NewForLocale("ja-JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range id.Tokens() {
+		if !slices.Contains(testAnimals, r) {
+			t.Fatalf("token %q is not from the ja alphabet", string(r))
+		}
+	}
+
+	// An unknown locale falls back to the default alphabet.
+	id, err = NewForLocale("xx-YY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(id.String()); err != nil {
+		t.Errorf("fallback ID does not parse with the default alphabet: %v", err)
+	}
+}
+
+func TestLookupAlphabetCopies(t *testing.T) {
+	registerForTest(t, "test.copy", testFaces)
+	a, ok := LookupAlphabet("test.copy")
+	if !ok {
+		t.Fatal("registered alphabet not found")
+	}
+	a[0] = 'x'
+	if b, _ := LookupAlphabet("test.copy"); b[0] != testFaces[0] {
+		t.Error("LookupAlphabet returned the registry's own slice")
+	}
+	if _, ok := LookupAlphabet("test.missing"); ok {
+		t.Error("unregistered name found")
+	}
+	if err := RegisterAlphabet("", testFaces); err == nil {
+		t.Error("empty name accepted")
+	}
+}
+
+// registerLayoutForTest registers l under name and removes it when t ends.
+func registerLayoutForTest(t *testing.T, name string, l Layout) {
+	t.Helper()
+	if err := RegisterLayout(name, l); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(layouts, name)
+	})
+}
+
+func TestValidateAnyLayout(t *testing.T) {
+	id := MustNew()
+	quads := Layout{4, 4, 4, 4, 4, 4, 4, 4}
+	s, err := id.FormatLayout(quads)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ValidateAnyLayout(id.String(), DefaultAlphabet) {
+		t.Error("default layout rejected")
+	}
+	if ValidateAnyLayout(s, DefaultAlphabet) {
+		t.Error("unregistered layout accepted")
+	}
+
+	registerLayoutForTest(t, "test.quads", quads)
+	if !ValidateAnyLayout(s, DefaultAlphabet) {
+		t.Error("registered layout rejected")
+	}
+	if ValidateAnyLayout(s, testFaces) {
+		t.Error("registered layout accepted with a foreign alphabet")
+	}
+}
+
+func TestRegisterLayout(t *testing.T) {
+	l := Layout{16, 16}
+	registerLayoutForTest(t, "test.halves", l)
+	l[0] = 1
+	got, ok := LookupLayout("test.halves")
+	if !ok || !slices.Equal(got, Layout{16, 16}) {
+		t.Errorf("LookupLayout = %v, %v; want [16 16]", got, ok)
+	}
+	got[0] = 1
+	if again, _ := LookupLayout("test.halves"); again[0] != 16 {
+		t.Error("LookupLayout returned the registry's own slice")
+	}
+
+	if err := RegisterLayout("", StandardLayout); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("empty name: got %v, want ErrInvalidLayout", err)
+	}
+	if err := RegisterLayout("test.bad", Layout{8, 8}); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("invalid layout: got %v, want ErrInvalidLayout", err)
+	}
+	if _, ok := LookupLayout("test.bad"); ok {
+		t.Error("invalid layout was registered")
+	}
+}
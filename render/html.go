@@ -0,0 +1,46 @@
+// Package render draws EmojiIDs for the web. It lives outside the core package so
+// that users who only generate and parse IDs do not import html/template or
+// encoding/xml.
+package render
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/pizza-power/emojid"
+)
+
+// HTML renders id as one span per token, with a data-index attribute giving the
+// token's position (0-31), and a separator span between the groups of the default
+// layout:
+//
+//	<span class="emojid"><span class="emojid-token" data-index="0">😀</span>...<span class="emojid-sep">-</span>...</span>
+//
+// Tokens are HTML-escaped, so IDs from arbitrary alphabets are safe to embed.
+// emojid.Nil renders as an empty outer span.
+func HTML(id emojid.EmojiID) template.HTML {
+	if id.IsZero() {
+		return `<span class="emojid"></span>`
+	}
+
+	var b strings.Builder
+	b.WriteString(`<span class="emojid">`)
+	i := 0
+	for g, group := range id.Groups() {
+		if g > 0 {
+			b.WriteString(`<span class="emojid-sep">-</span>`)
+		}
+		for _, r := range group {
+			b.WriteString(`<span class="emojid-token" data-index="`)
+			b.WriteString(strconv.Itoa(i))
+			b.WriteString(`">`)
+			b.WriteString(template.HTMLEscapeString(string(r)))
+			b.WriteString(`</span>`)
+			i++
+		}
+	}
+	b.WriteString(`</span>`)
+
+	return template.HTML(b.String())
+}
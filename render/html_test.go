@@ -0,0 +1,49 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pizza-power/emojid"
+)
+
+func TestHTML(t *testing.T) {
+	id := emojid.MustNew()
+	got := string(HTML(id))
+
+	if !strings.HasPrefix(got, `<span class="emojid">`) || !strings.HasSuffix(got, `</span>`) {
+		t.Errorf("missing outer span: %s", got)
+	}
+	if n := strings.Count(got, `class="emojid-token"`); n != emojid.TokenCount {
+		t.Errorf("%d token spans, want %d", n, emojid.TokenCount)
+	}
+	if n := strings.Count(got, `class="emojid-sep"`); n != len(emojid.DefaultLayout())-1 {
+		t.Errorf("%d separator spans, want %d", n, len(emojid.DefaultLayout())-1)
+	}
+
+	for i, r := range id.Tokens() {
+		span := fmt.Sprintf(`data-index="%d">%s</span>`, i, string(r))
+		if !strings.Contains(got, span) {
+			t.Errorf("missing %s", span)
+		}
+	}
+}
+
+func TestHTMLEscapesTokens(t *testing.T) {
+	alphabet := []rune{'<', '&'}
+	id, err := emojid.FromIndices(make([]int, emojid.TokenCount), alphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(HTML(id))
+	if strings.Contains(got, `"><</span>`) || !strings.Contains(got, `&lt;`) {
+		t.Errorf("token not escaped: %s", got)
+	}
+}
+
+func TestHTMLNil(t *testing.T) {
+	if got, want := string(HTML(emojid.Nil)), `<span class="emojid"></span>`; got != want {
+		t.Errorf("HTML(Nil) = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package render
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/pizza-power/emojid"
+)
+
+// SVGOptions configures SVG. Zero fields take their defaults.
+type SVGOptions struct {
+	// FontSize is the emoji size in user units. Default 24.
+	FontSize float64
+	// Spacing is the extra space between adjacent tokens. Default 0.
+	Spacing float64
+	// GroupGap is the extra space between the groups of the default layout, in
+	// place of the '-' separator. Default FontSize/2.
+	GroupGap float64
+}
+
+// SVG renders id as a standalone <svg> document with one <text> element per token,
+// positioned left to right, for crisp IDs in docs and emails:
+//
+//	<svg xmlns="http://www.w3.org/2000/svg" width="..." height="..." class="emojid"><text x="0" y="24" font-size="24">😀</text>...</svg>
+//
+// Tokens are XML-escaped. The width assumes every emoji is one em wide, which
+// holds for emoji fonts. emojid.Nil renders as an empty document of zero width.
+func SVG(id emojid.EmojiID, opts SVGOptions) string {
+	size := opts.FontSize
+	if size <= 0 {
+		size = 24
+	}
+	gap := opts.GroupGap
+	if gap <= 0 {
+		gap = size / 2
+	}
+
+	var body strings.Builder
+	x := 0.0
+	if !id.IsZero() {
+		for g, group := range id.Groups() {
+			if g > 0 {
+				x += gap - opts.Spacing
+			}
+			for _, r := range group {
+				body.WriteString(`<text x="`)
+				body.WriteString(formatFloat(x))
+				body.WriteString(`" y="`)
+				body.WriteString(formatFloat(size))
+				body.WriteString(`" font-size="`)
+				body.WriteString(formatFloat(size))
+				body.WriteString(`">`)
+				xml.EscapeText(&body, []byte(string(r)))
+				body.WriteString(`</text>`)
+				x += size + opts.Spacing
+			}
+		}
+		x -= opts.Spacing
+	}
+
+	var b strings.Builder
+	b.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="`)
+	b.WriteString(formatFloat(x))
+	b.WriteString(`" height="`)
+	b.WriteString(formatFloat(size * 1.25))
+	b.WriteString(`" class="emojid">`)
+	b.WriteString(body.String())
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pizza-power/emojid"
+)
+
+func TestSVG(t *testing.T) {
+	id := emojid.MustNew()
+	doc := SVG(id, SVGOptions{})
+
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	var texts []string
+	var inText bool
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v\n%s", err, doc)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			inText = tok.Name.Local == "text"
+			if inText {
+				texts = append(texts, "")
+			}
+		case xml.CharData:
+			if inText {
+				texts[len(texts)-1] += string(tok)
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+
+	if len(texts) != emojid.TokenCount {
+		t.Fatalf("%d text elements, want %d", len(texts), emojid.TokenCount)
+	}
+	for i, r := range id.Tokens() {
+		if texts[i] != string(r) {
+			t.Errorf("text %d = %q, want %q", i, texts[i], string(r))
+		}
+	}
+
+	// 32 tokens of 24 and 4 gaps of 12.
+	if !strings.Contains(doc, `width="816" height="30"`) {
+		t.Errorf("unexpected size: %s", doc[:strings.Index(doc, ">")+1])
+	}
+}
+
+func TestSVGOptions(t *testing.T) {
+	id := emojid.MustNew()
+	doc := SVG(id, SVGOptions{FontSize: 10, Spacing: 2, GroupGap: 5})
+	// 32*10 + 31*2 + 4*(5-2) = 394.
+	if !strings.Contains(doc, `width="394" height="12.5"`) {
+		t.Errorf("unexpected size: %s", doc[:strings.Index(doc, ">")+1])
+	}
+	if n := strings.Count(doc, `font-size="10"`); n != emojid.TokenCount {
+		t.Errorf("%d tokens at font size 10, want %d", n, emojid.TokenCount)
+	}
+}
+
+func TestSVGEscapesTokens(t *testing.T) {
+	id, err := emojid.FromIndices(make([]int, emojid.TokenCount), []rune{'<', '&'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal([]byte(SVG(id, SVGOptions{})), new(struct{})); err != nil {
+		t.Errorf("escaped SVG is invalid XML: %v", err)
+	}
+}
+
+func TestSVGNil(t *testing.T) {
+	doc := SVG(emojid.Nil, SVGOptions{})
+	if strings.Contains(doc, "<text") || !strings.Contains(doc, `width="0"`) {
+		t.Errorf("SVG(Nil) = %s", doc)
+	}
+}
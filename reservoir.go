@@ -0,0 +1,59 @@
+package emojid
+
+import (
+	"crypto/rand"
+	mrand "math/rand/v2"
+)
+
+// Reservoir keeps a uniform random sample of at most k IDs from a stream of
+// unknown length, e.g. for auditing a sample of issued IDs. After n offers every
+// offered ID is in the sample with probability k/n (Vitter's algorithm R).
+//
+// The sampling decisions come from a ChaCha8 generator seeded from crypto/rand,
+// so the sample cannot be predicted from the offered IDs. A Reservoir is not safe
+// for concurrent use.
+type Reservoir struct {
+	sample []EmojiID
+	k      int
+	seen   uint64
+	rng    *mrand.Rand
+}
+
+// NewReservoir returns an empty Reservoir holding at most k IDs. k below 1 is
+// raised to 1.
+func NewReservoir(k int) *Reservoir {
+	if k < 1 {
+		k = 1
+	}
+
+	var seed [32]byte
+	rand.Read(seed[:])
+	return &Reservoir{
+		sample: make([]EmojiID, 0, k),
+		k:      k,
+		rng:    mrand.New(mrand.NewChaCha8(seed)),
+	}
+}
+
+// Offer considers id for the sample.
+func (r *Reservoir) Offer(id EmojiID) {
+	r.seen++
+	if len(r.sample) < r.k {
+		r.sample = append(r.sample, id)
+		return
+	}
+	if j := r.rng.Uint64N(r.seen); j < uint64(r.k) {
+		r.sample[j] = id
+	}
+}
+
+// Sample returns a copy of the current sample, in no particular order. It holds
+// min(k, offers) IDs.
+func (r *Reservoir) Sample() []EmojiID {
+	return append([]EmojiID(nil), r.sample...)
+}
+
+// Seen returns the number of IDs offered so far.
+func (r *Reservoir) Seen() uint64 {
+	return r.seen
+}
@@ -0,0 +1,57 @@
+package emojid
+
+import "testing"
+
+func TestReservoirSize(t *testing.T) {
+	r := NewReservoir(5)
+	for i := range 12 {
+		r.Offer(MustNew())
+		if got, want := len(r.Sample()), min(i+1, 5); got != want {
+			t.Fatalf("after %d offers: sample has %d IDs, want %d", i+1, got, want)
+		}
+	}
+	if r.Seen() != 12 {
+		t.Errorf("Seen() = %d, want 12", r.Seen())
+	}
+
+	r.Sample()[0] = Nil
+	if r.Sample()[0] == Nil {
+		t.Error("Sample does not return a copy")
+	}
+	if got := cap(NewReservoir(0).sample); got != 1 {
+		t.Errorf("k=0 holds %d IDs, want 1", got)
+	}
+}
+
+func TestReservoirUniform(t *testing.T) {
+	const (
+		n      = 50
+		k      = 5
+		trials = 4000
+	)
+	ids := make([]EmojiID, n)
+	pos := make(map[EmojiID]int, n)
+	for i := range ids {
+		ids[i] = MustNew()
+		pos[ids[i]] = i
+	}
+
+	var counts [n]int
+	for range trials {
+		r := NewReservoir(k)
+		for _, id := range ids {
+			r.Offer(id)
+		}
+		for _, id := range r.Sample() {
+			counts[pos[id]]++
+		}
+	}
+
+	// Each ID is expected trials*k/n = 400 times with a standard deviation of
+	// about 19; allow 6 standard deviations.
+	for i, c := range counts {
+		if c < 285 || c > 515 {
+			t.Errorf("ID %d sampled %d times, want about 400", i, c)
+		}
+	}
+}
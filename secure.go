@@ -0,0 +1,20 @@
+package emojid
+
+import "fmt"
+
+// MinSecureEntropyBits is the minimum entropy, in bits, that NewSecure requires of
+// an alphabet. The default of 128 bits matches a random UUID and is met by any
+// alphabet with at least 16 distinct entries. Set it during program
+// initialization; it is not safe to change concurrently with NewSecure.
+var MinSecureEntropyBits = 128.0
+
+// NewSecure is like NewWithAlphabet but returns ErrInsufficientEntropy unless the
+// distinct entries of alphabet yield at least MinSecureEntropyBits, for callers
+// that use IDs as secrets or capability tokens. Duplicate entries do not add
+// entropy and are not counted. NewWithAlphabet remains permissive.
+func NewSecure(alphabet []rune) (EmojiID, error) {
+	if bits := EntropyBits(len(cachedAlphabetSet(alphabet))); bits < MinSecureEntropyBits {
+		return EmojiID{}, fmt.Errorf("%w: %.1f bits, need %.1f", ErrInsufficientEntropy, bits, MinSecureEntropyBits)
+	}
+	return NewWithAlphabet(alphabet)
+}
@@ -0,0 +1,35 @@
+package emojid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewSecure(t *testing.T) {
+	// 16 distinct entries give exactly 32 * 4 = 128 bits.
+	if _, err := NewSecure(DefaultAlphabet[:16]); err != nil {
+		t.Errorf("16 entries: %v", err)
+	}
+	if _, err := NewSecure(DefaultAlphabet[:15]); !errors.Is(err, ErrInsufficientEntropy) {
+		t.Errorf("15 entries: got %v, want ErrInsufficientEntropy", err)
+	}
+	// Duplicates do not count.
+	padded := append(DefaultAlphabet[:15:15], DefaultAlphabet[0])
+	if _, err := NewSecure(padded); !errors.Is(err, ErrInsufficientEntropy) {
+		t.Errorf("15 distinct of 16: got %v, want ErrInsufficientEntropy", err)
+	}
+}
+
+func TestNewSecureThreshold(t *testing.T) {
+	old := MinSecureEntropyBits
+	t.Cleanup(func() { MinSecureEntropyBits = old })
+
+	MinSecureEntropyBits = 96 // 8 entries give 96 bits
+	if _, err := NewSecure(testFaces); err != nil {
+		t.Errorf("at threshold: %v", err)
+	}
+	MinSecureEntropyBits = 96.5
+	if _, err := NewSecure(testFaces); !errors.Is(err, ErrInsufficientEntropy) {
+		t.Errorf("below threshold: got %v, want ErrInsufficientEntropy", err)
+	}
+}
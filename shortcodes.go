@@ -0,0 +1,113 @@
+package emojid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shortcodes maps every DefaultAlphabet emoji to its GitHub/Slack-style shortcode
+// name (without the surrounding colons).
+var shortcodes = map[rune]string{
+	'😀': "grinning", '😃': "smiley", '😄': "smile", '😁': "grin",
+	'😆': "laughing", '😅': "sweat_smile", '😂': "joy", '🤣': "rofl",
+	'😊': "blush", '😇': "innocent", '🙂': "slightly_smiling_face", '🙃': "upside_down_face",
+	'😉': "wink", '😌': "relieved", '😍': "heart_eyes", '🥰': "smiling_face_with_three_hearts",
+	'😘': "kissing_heart", '😗': "kissing", '😙': "kissing_smiling_eyes", '😚': "kissing_closed_eyes",
+	'😋': "yum", '😛': "stuck_out_tongue", '😝': "stuck_out_tongue_closed_eyes", '😜': "stuck_out_tongue_winking_eye",
+	'🤪': "zany_face", '🤨': "raised_eyebrow", '🧐': "monocle_face", '🤓': "nerd_face",
+	'😎': "sunglasses", '🥳': "partying_face", '😤': "triumph", '😡': "rage",
+	'🤯': "exploding_head", '😱': "scream", '😴': "sleeping", '🤤': "drooling_face",
+	'😷': "mask", '🤒': "face_with_thermometer", '🤕': "face_with_head_bandage", '🤠': "cowboy_hat_face",
+	'😈': "smiling_imp", '👻': "ghost", '🤖': "robot", '🎃': "jack_o_lantern",
+	'🐶': "dog", '🐱': "cat", '🐭': "mouse", '🐹': "hamster",
+	'🐰': "rabbit", '🦊': "fox_face", '🐻': "bear", '🐼': "panda_face",
+	'🐨': "koala", '🐯': "tiger", '🦁': "lion", '🐸': "frog",
+	'🐵': "monkey_face", '🐔': "chicken", '🐧': "penguin", '🐦': "bird",
+	'🐤': "baby_chick", '🐙': "octopus", '🦑': "squid", '🦀': "crab",
+	'🐠': "tropical_fish", '🐳': "whale", '🦋': "butterfly", '🐞': "lady_beetle",
+	'🌸': "cherry_blossom", '🌼': "blossom", '🌻': "sunflower", '🌺': "hibiscus",
+	'🍎': "apple", '🍊': "tangerine", '🍋': "lemon", '🍉': "watermelon",
+	'🍇': "grapes", '🍓': "strawberry", '🍒': "cherries", '🍍': "pineapple",
+	'🥑': "avocado", '🥦': "broccoli", '🥕': "carrot", '🌶': "hot_pepper",
+	'🍔': "hamburger", '🍟': "fries", '🍕': "pizza", '🌮': "taco",
+	'🍣': "sushi", '🍩': "doughnut", '🍪': "cookie", '🍫': "chocolate_bar",
+	'🍿': "popcorn", '☕': "coffee", '🍺': "beer", '🍷': "wine_glass",
+	'⚽': "soccer", '🏀': "basketball", '🏈': "football", '⚾': "baseball",
+	'🎾': "tennis", '🏐': "volleyball", '🎱': "8ball", '🏓': "ping_pong",
+	'🎸': "guitar", '🎹': "musical_keyboard", '🥁': "drum", '🎻': "violin",
+	'🎧': "headphones", '🎮': "video_game", '🧩': "jigsaw", '🎲': "game_die",
+	'🚗': "car", '🚕': "taxi", '🚌': "bus", '🚑': "ambulance",
+	'🚒': "fire_engine", '🚜': "tractor", '✈': "airplane", '🚀': "rocket",
+	'🛰': "artificial_satellite", '⛵': "sailboat", '🚲': "bike", '🛴': "kick_scooter",
+	'🏠': "house", '🏢': "office", '🏭': "factory", '🏰': "european_castle",
+	'🌍': "earth_africa", '🌙': "crescent_moon", '⭐': "star", '⚡': "zap",
+	'🔥': "fire", '💧': "droplet", '🌈': "rainbow", '❄': "snowflake",
+	'💎': "gem", '🔒': "lock", '🔑': "key", '🧠': "brain",
+	'💡': "bulb", '📦': "package", '🧲': "magnet", '🧰': "toolbox",
+	'🛡': "shield", '⚙': "gear", '🧪': "test_tube", '🧬': "dna",
+	'🔭': "telescope", '📡': "satellite", '💾': "floppy_disk", '🗄': "file_cabinet",
+}
+
+// shortcodeRunes is the reverse of shortcodes.
+var shortcodeRunes = func() map[string]rune {
+	m := make(map[string]rune, len(shortcodes))
+	for r, name := range shortcodes {
+		m[name] = r
+	}
+	return m
+}()
+
+// ToShortcodes formats the EmojiID with every token written as its shortcode, for
+// storage that keeps emoji as :name: text, e.g. ":grinning::smiley:...-:dog:...".
+// Groups are separated by '-' as in String. Tokens outside DefaultAlphabet have no
+// shortcode and are written as the emoji itself. Nil is written as the empty
+// string.
+func (e EmojiID) ToShortcodes() string {
+	if e.IsZero() {
+		return ""
+	}
+
+	var b strings.Builder
+	for g, group := range e.Groups() {
+		if g > 0 {
+			b.WriteByte('-')
+		}
+		for _, r := range group {
+			if name, ok := shortcodes[r]; ok {
+				b.WriteString(":" + name + ":")
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// ParseShortcodes parses an ID in the form produced by ToShortcodes: shortcodes
+// and plain emoji may be mixed, and groups are separated by '-'. Every token must
+// be in alphabet. An unknown shortcode is reported as ErrInvalidToken.
+func ParseShortcodes(s string, alphabet []rune) (EmojiID, error) {
+	var b strings.Builder
+	for s != "" {
+		start := strings.IndexByte(s, ':')
+		if start < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:start])
+
+		end := strings.IndexByte(s[start+1:], ':')
+		if end < 0 {
+			return EmojiID{}, fmt.Errorf("%w: unterminated shortcode", ErrInvalidFormat)
+		}
+		name := s[start+1 : start+1+end]
+		r, ok := shortcodeRunes[name]
+		if !ok {
+			return EmojiID{}, fmt.Errorf("%w: unknown shortcode :%s:", ErrInvalidToken, name)
+		}
+		b.WriteRune(r)
+		s = s[start+end+2:]
+	}
+
+	return ParseWithAlphabet(b.String(), alphabet)
+}
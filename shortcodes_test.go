@@ -0,0 +1,66 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestShortcodesRoundTrip(t *testing.T) {
+	if len(shortcodeRunes) != len(DefaultAlphabet) {
+		t.Errorf("%d distinct shortcodes for %d emoji", len(shortcodeRunes), len(DefaultAlphabet))
+	}
+	for _, r := range DefaultAlphabet {
+		if _, ok := shortcodes[r]; !ok {
+			t.Errorf("%q has no shortcode", string(r))
+		}
+	}
+
+	for range 20 {
+		id := MustNew()
+		s := id.ToShortcodes()
+		if strings.ContainsFunc(s, func(r rune) bool { return r > 0x7f }) {
+			t.Fatalf("ToShortcodes() = %q contains non-ASCII", s)
+		}
+		got, err := ParseShortcodes(s, DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != id {
+			t.Fatalf("round trip = %v, want %v", got, id)
+		}
+	}
+}
+
+func TestParseShortcodesMixed(t *testing.T) {
+	id := MustNew()
+	s := id.String()
+	first := id.Tokens()[0]
+	mixed := ":" + shortcodes[first] + ":" + strings.TrimPrefix(s, string(first))
+	got, err := ParseShortcodes(mixed, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("mixed = %v, want %v", got, id)
+	}
+}
+
+func TestParseShortcodesErrors(t *testing.T) {
+	s := MustNew().ToShortcodes()
+	for _, tc := range []struct {
+		in   string
+		want error
+	}{
+		{":nonexistent:" + s[strings.Index(s[1:], ":")+2:], ErrInvalidToken},
+		{s[:len(s)-1], ErrInvalidFormat},
+		{"", ErrInvalidFormat},
+	} {
+		if _, err := ParseShortcodes(tc.in, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("ParseShortcodes(%q): got %v, want %v", tc.in, err, tc.want)
+		}
+	}
+	if got := Nil.ToShortcodes(); got != "" {
+		t.Errorf("Nil.ToShortcodes() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,46 @@
+package emojid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Sign returns the ID as a tamper-evident token "<id>.<tag>", where id is String
+// and tag is the unpadded base64url encoding of HMAC-SHA256(key, id). Anyone can
+// read the ID; only holders of key can produce a valid tag. Use a random key of at
+// least 32 bytes.
+func (e EmojiID) Sign(key []byte) string {
+	s := e.String()
+	return s + "." + base64.RawURLEncoding.EncodeToString(signTag(key, s))
+}
+
+// VerifySigned checks a token produced by Sign with key and returns its ID. The
+// tag is compared in constant time before the ID is parsed; a wrong key, altered
+// ID or altered tag returns ErrSignatureMismatch. The ID must then parse in the
+// default layout with alphabet, and the alphabet must not contain '.'.
+func VerifySigned(s string, key []byte, alphabet []rune) (EmojiID, error) {
+	i := strings.LastIndexByte(s, '.')
+	if i < 0 {
+		return EmojiID{}, fmt.Errorf("%w: missing signature", ErrInvalidFormat)
+	}
+	idPart, tagPart := s[:i], s[i+1:]
+
+	tag, err := base64.RawURLEncoding.DecodeString(tagPart)
+	if err != nil {
+		return EmojiID{}, fmt.Errorf("%w: malformed tag", ErrSignatureMismatch)
+	}
+	if !hmac.Equal(tag, signTag(key, idPart)) {
+		return EmojiID{}, ErrSignatureMismatch
+	}
+	return ParseWithAlphabet(idPart, alphabet)
+}
+
+// signTag returns HMAC-SHA256(key, s).
+func signTag(key []byte, s string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(s))
+	return mac.Sum(nil)
+}
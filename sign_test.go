@@ -0,0 +1,59 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSignRoundTrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	id := MustNew()
+	token := id.Sign(key)
+	if !strings.HasPrefix(token, id.String()+".") {
+		t.Errorf("Sign() = %q", token)
+	}
+	got, err := VerifySigned(token, key, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("VerifySigned = %v, want %v", got, id)
+	}
+}
+
+func TestVerifySignedRejects(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	id := MustNew()
+	token := id.Sign(key)
+	idPart, tag, _ := strings.Cut(token, ".")
+
+	other := MustNew()
+	flipped := []byte(tag)
+	flipped[0] ^= 'A' ^ 'B'
+	if flipped[0] == tag[0] {
+		flipped[0] = 'C'
+	}
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		key   []byte
+		want  error
+	}{
+		{"wrong key", token, []byte("another key of at least 32 bytes!"), ErrSignatureMismatch},
+		{"swapped ID", other.String() + "." + tag, key, ErrSignatureMismatch},
+		{"altered tag", idPart + "." + string(flipped), key, ErrSignatureMismatch},
+		{"malformed tag", idPart + ".***", key, ErrSignatureMismatch},
+		{"missing tag", idPart, key, ErrInvalidFormat},
+	} {
+		if _, err := VerifySigned(tc.token, tc.key, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	// A valid tag over an ID outside the alphabet still fails to parse.
+	if _, err := VerifySigned(token, key, testFaces); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign alphabet: got %v, want ErrInvalidToken", err)
+	}
+}
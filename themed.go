@@ -0,0 +1,79 @@
+package emojid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// NewThemed returns a new random EmojiID whose tokens mostly come from a theme,
+// e.g. food emoji, with occasional others for variety: each token is drawn from
+// primary with probability primaryBias and from secondary otherwise, uniformly
+// within the chosen set. Every entry of primary and secondary must be in alphabet,
+// so the IDs parse with ParseWithAlphabet, and together they must pass
+// ValidateAlphabet, which rules out entries shared between the two sets.
+//
+// The bias costs entropy; ThemedEntropyBits reports how much is left, and
+// NewThemed returns ErrInsufficientEntropy when that is below
+// MinSecureEntropyBits (128 by default).
+func NewThemed(primary, secondary []rune, primaryBias float64, alphabet []rune) (EmojiID, error) {
+	if !(primaryBias >= 0 && primaryBias <= 1) {
+		return EmojiID{}, fmt.Errorf("%w: primary bias %v outside [0, 1]", ErrConstraintInfeasible, primaryBias)
+	}
+	if err := ValidateAlphabet(slices.Concat(primary, secondary)); err != nil {
+		return EmojiID{}, err
+	}
+	allowed := cachedAlphabetSet(alphabet)
+	for _, r := range slices.Concat(primary, secondary) {
+		if _, ok := allowed[r]; !ok {
+			return EmojiID{}, fmt.Errorf("%w: %q is not in alphabet", ErrInvalidAlphabet, string(r))
+		}
+	}
+	if bits := ThemedEntropyBits(len(primary), len(secondary), primaryBias); bits < MinSecureEntropyBits {
+		return EmojiID{}, fmt.Errorf("%w: %.1f bits, need %.1f", ErrInsufficientEntropy, bits, MinSecureEntropyBits)
+	}
+
+	var id EmojiID
+	for i := range id.tokens {
+		var buf [8]byte
+		if _, err := io.ReadFull(rand.Reader, buf[:]); err != nil {
+			return EmojiID{}, ErrEntropyFailure
+		}
+		// 53 random bits give a uniform float64 in [0, 1).
+		u := float64(binary.BigEndian.Uint64(buf[:])>>11) / (1 << 53)
+
+		set := secondary
+		if u < primaryBias {
+			set = primary
+		}
+		idx, err := cryptoRandIndex(len(set))
+		if err != nil {
+			return EmojiID{}, err
+		}
+		id.tokens[i] = set[idx]
+	}
+	return id, nil
+}
+
+// ThemedEntropyBits returns the entropy in bits of an EmojiID from NewThemed with
+// primary and secondary sets of the given sizes: per token, the entropy of the
+// set choice plus the weighted entropy of the choice within the set. An empty set
+// that can still be chosen yields 0.
+func ThemedEntropyBits(primarySize, secondarySize int, primaryBias float64) float64 {
+	p, q := primaryBias, 1-primaryBias
+	if (p > 0 && primarySize < 1) || (q > 0 && secondarySize < 1) {
+		return 0
+	}
+
+	bits := 0.0
+	if p > 0 {
+		bits += p * (math.Log2(float64(primarySize)) - math.Log2(p))
+	}
+	if q > 0 {
+		bits += q * (math.Log2(float64(secondarySize)) - math.Log2(q))
+	}
+	return TokenCount * bits
+}
@@ -0,0 +1,63 @@
+package emojid
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNewThemedBias(t *testing.T) {
+	primary, secondary := DefaultAlphabet[:100], DefaultAlphabet[100:]
+	var fromPrimary, total int
+	for range 200 {
+		id, err := NewThemed(primary, secondary, 0.9, DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range id.Tokens() {
+			if slices.Contains(primary, r) {
+				fromPrimary++
+			}
+			total++
+		}
+	}
+	// The standard deviation of the share over 6400 tokens is about 0.004.
+	if share := float64(fromPrimary) / float64(total); share < 0.87 || share > 0.93 {
+		t.Errorf("%.3f of tokens from primary, want about 0.9", share)
+	}
+}
+
+func TestNewThemedErrors(t *testing.T) {
+	primary, secondary := DefaultAlphabet[:100], DefaultAlphabet[100:]
+	for _, tc := range []struct {
+		name               string
+		primary, secondary []rune
+		bias               float64
+		alphabet           []rune
+		want               error
+	}{
+		{"bias above 1", primary, secondary, 1.5, DefaultAlphabet, ErrConstraintInfeasible},
+		{"NaN bias", primary, secondary, math.NaN(), DefaultAlphabet, ErrConstraintInfeasible},
+		{"overlapping sets", primary, DefaultAlphabet[99:], 0.5, DefaultAlphabet, ErrInvalidAlphabet},
+		{"outside alphabet", primary, []rune{'🕐', '🕑'}, 0.5, DefaultAlphabet, ErrInvalidAlphabet},
+		{"too little entropy", testFaces, testAnimals, 1, slices.Concat(testFaces, testAnimals), ErrInsufficientEntropy},
+	} {
+		if _, err := NewThemed(tc.primary, tc.secondary, tc.bias, tc.alphabet); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestThemedEntropyBits(t *testing.T) {
+	// An unbiased choice between two halves equals a uniform alphabet.
+	if got, want := ThemedEntropyBits(76, 76, 0.5), EntropyBits(152); math.Abs(got-want) > 1e-9 {
+		t.Errorf("even split = %v, want %v", got, want)
+	}
+	if got, want := ThemedEntropyBits(16, 0, 1), 128.0; got != want {
+		t.Errorf("primary only = %v, want %v", got, want)
+	}
+	if got := ThemedEntropyBits(0, 16, 0.5); got != 0 {
+		t.Errorf("empty primary = %v, want 0", got)
+	}
+}
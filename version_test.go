@@ -0,0 +1,72 @@
+package emojid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVersionedRoundTrip(t *testing.T) {
+	for version := 1; version <= len(VersionAlphabet); version++ {
+		s, err := NewVersioned(version, DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse accepted versioned %q", s)
+		}
+		id, got, err := ParseVersioned(s, DefaultAlphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != version {
+			t.Errorf("version = %d, want %d", got, version)
+		}
+		if want := string(VersionAlphabet[version-1]) + "-" + id.String(); want != s {
+			t.Errorf("ParseVersioned(%q) = %v", s, id)
+		}
+	}
+}
+
+func TestVersionedNil(t *testing.T) {
+	s, err := Nil.FormatVersioned(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "🕑-" {
+		t.Errorf("FormatVersioned(Nil) = %q, want %q", s, "🕑-")
+	}
+	id, version, err := ParseVersioned(s, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != Nil || version != 2 {
+		t.Errorf("ParseVersioned(%q) = %v, %d; want Nil, 2", s, id, version)
+	}
+}
+
+func TestVersionedErrors(t *testing.T) {
+	id := MustNew()
+	for _, v := range []int{0, 13} {
+		if _, err := id.FormatVersioned(v); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("version %d: got %v, want ErrInvalidVersion", v, err)
+		}
+	}
+	if _, err := NewVersioned(1, append([]rune{'🕐'}, testFaces...)); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Errorf("alphabet with a version token: got %v, want ErrInvalidAlphabet", err)
+	}
+
+	for _, tc := range []struct {
+		in   string
+		want error
+	}{
+		{"😀-" + id.String(), ErrInvalidVersion},
+		{id.String(), ErrInvalidFormat},
+		{strings.Repeat("🕐", 2) + "-" + id.String(), ErrInvalidFormat},
+		{"🕐" + id.String(), ErrInvalidFormat},
+	} {
+		if _, _, err := ParseVersioned(tc.in, DefaultAlphabet); !errors.Is(err, tc.want) {
+			t.Errorf("ParseVersioned(%q): got %v, want %v", tc.in, err, tc.want)
+		}
+	}
+}
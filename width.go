@@ -0,0 +1,95 @@
+package emojid
+
+import (
+	"strings"
+	"unicode"
+)
+
+// emojiPresentation holds the code points with Emoji_Presentation=Yes (Unicode
+// 15.1 emoji-data.txt), i.e. single code points that render as emoji without a
+// variation selector. Regional indicators and skin tone modifiers are left out
+// because they only make sense as parts of a sequence.
+var emojiPresentation = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x231A, 0x231B, 1}, {0x23E9, 0x23EC, 1}, {0x23F0, 0x23F3, 3},
+		{0x25FD, 0x25FE, 1}, {0x2614, 0x2615, 1}, {0x2648, 0x2653, 1},
+		{0x267F, 0x2693, 20}, {0x26A1, 0x26A1, 1}, {0x26AA, 0x26AB, 1},
+		{0x26BD, 0x26BE, 1}, {0x26C4, 0x26C5, 1}, {0x26CE, 0x26D4, 6},
+		{0x26EA, 0x26EA, 1}, {0x26F2, 0x26F3, 1}, {0x26F5, 0x26FA, 5},
+		{0x26FD, 0x26FD, 1}, {0x2705, 0x2705, 1}, {0x270A, 0x270B, 1},
+		{0x2728, 0x2728, 1}, {0x274C, 0x274E, 2}, {0x2753, 0x2755, 1},
+		{0x2757, 0x2757, 1}, {0x2795, 0x2797, 1}, {0x27B0, 0x27BF, 15},
+		{0x2B1B, 0x2B1C, 1}, {0x2B50, 0x2B55, 5},
+	},
+	R32: []unicode.Range32{
+		{0x1F004, 0x1F004, 1}, {0x1F0CF, 0x1F0CF, 1}, {0x1F18E, 0x1F18E, 1},
+		{0x1F191, 0x1F19A, 1}, {0x1F201, 0x1F201, 1}, {0x1F21A, 0x1F21A, 1},
+		{0x1F22F, 0x1F22F, 1}, {0x1F232, 0x1F236, 1}, {0x1F238, 0x1F23A, 1},
+		{0x1F250, 0x1F251, 1}, {0x1F300, 0x1F320, 1}, {0x1F32D, 0x1F335, 1},
+		{0x1F337, 0x1F37C, 1}, {0x1F37E, 0x1F393, 1}, {0x1F3A0, 0x1F3CA, 1},
+		{0x1F3CF, 0x1F3D3, 1}, {0x1F3E0, 0x1F3F0, 1}, {0x1F3F4, 0x1F3F4, 1},
+		{0x1F3F8, 0x1F3FA, 1}, {0x1F400, 0x1F43E, 1}, {0x1F440, 0x1F440, 1},
+		{0x1F442, 0x1F4FC, 1}, {0x1F4FF, 0x1F53D, 1}, {0x1F54B, 0x1F54E, 1},
+		{0x1F550, 0x1F567, 1}, {0x1F57A, 0x1F57A, 1}, {0x1F595, 0x1F596, 1},
+		{0x1F5A4, 0x1F5A4, 1}, {0x1F5FB, 0x1F64F, 1}, {0x1F680, 0x1F6C5, 1},
+		{0x1F6CC, 0x1F6CC, 1}, {0x1F6D0, 0x1F6D2, 1}, {0x1F6D5, 0x1F6D7, 1},
+		{0x1F6DC, 0x1F6DF, 1}, {0x1F6EB, 0x1F6EC, 1}, {0x1F6F4, 0x1F6FC, 1},
+		{0x1F7E0, 0x1F7EB, 1}, {0x1F7F0, 0x1F7F0, 1}, {0x1F90C, 0x1F93A, 1},
+		{0x1F93C, 0x1F945, 1}, {0x1F947, 0x1F9FF, 1}, {0x1FA70, 0x1FA7C, 1},
+		{0x1FA80, 0x1FA88, 1}, {0x1FA90, 0x1FABD, 1}, {0x1FABF, 0x1FAC5, 1},
+		{0x1FACE, 0x1FADB, 1}, {0x1FAE0, 0x1FAE8, 1}, {0x1FAF0, 0x1FAF8, 1},
+	},
+}
+
+// eastAsianWide holds the non-emoji East Asian Wide and Fullwidth blocks.
+var eastAsianWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1100, 0x115F, 1}, {0x2E80, 0x303E, 1}, {0x3041, 0x33FF, 1},
+		{0x3400, 0x4DBF, 1}, {0x4E00, 0x9FFF, 1}, {0xA000, 0xA4CF, 1},
+		{0xAC00, 0xD7A3, 1}, {0xF900, 0xFAFF, 1}, {0xFE30, 0xFE4F, 1},
+		{0xFF00, 0xFF60, 1}, {0xFFE0, 0xFFE6, 1},
+	},
+	R32: []unicode.Range32{
+		{0x20000, 0x2FFFD, 1}, {0x30000, 0x3FFFD, 1},
+	},
+}
+
+// runeWidth returns the number of terminal columns r occupies: 0 for controls,
+// combining marks and format characters, 2 for emoji-presentation and East Asian
+// wide code points, and 1 otherwise. Symbols that only render as emoji with
+// U+FE0F, such as ✈ or ❄, count as 1.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.In(r, emojiPresentation, eastAsianWide):
+		return 2
+	}
+	return 1
+}
+
+// stringWidth returns the number of terminal columns s occupies.
+func stringWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// PadTo returns String padded with trailing spaces to the given display width in
+// terminal columns, for fixed-width columns. If the ID is already at least that
+// wide it is returned unpadded. ParsePadded reverses it.
+func (e EmojiID) PadTo(width int) string {
+	s := e.String()
+	if pad := width - stringWidth(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// ParsePadded parses an ID read back from a fixed-width column, such as one
+// produced by PadTo, after trimming the trailing space padding.
+func ParsePadded(s string, alphabet []rune) (EmojiID, error) {
+	return ParseWithAlphabet(strings.TrimRight(s, " "), alphabet)
+}
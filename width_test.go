@@ -0,0 +1,31 @@
+package emojid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPadToRoundTrip(t *testing.T) {
+	id := MustNew()
+	w := stringWidth(id.String())
+	for _, width := range []int{0, w, w + 1, w + 20} {
+		s := id.PadTo(width)
+		if got := stringWidth(s); got != max(width, w) {
+			t.Errorf("PadTo(%d) has width %d", width, got)
+		}
+		got, err := ParsePadded(s, DefaultAlphabet)
+		if err != nil {
+			t.Fatalf("ParsePadded(PadTo(%d)): %v", width, err)
+		}
+		if got != id {
+			t.Errorf("PadTo(%d) round trip = %v, want %v", width, got, id)
+		}
+	}
+
+	if _, err := ParsePadded(" "+id.PadTo(w+4), DefaultAlphabet); err == nil {
+		t.Error("leading space accepted")
+	}
+	if !strings.HasSuffix(id.PadTo(w+3), "   ") {
+		t.Error("PadTo does not pad with trailing spaces")
+	}
+}
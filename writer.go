@@ -0,0 +1,31 @@
+package emojid
+
+import (
+	"bufio"
+	"io"
+)
+
+// IDWriter writes EmojiIDs one per line to an io.Writer for bulk export. It
+// formats into a reused buffer and batches writes, so writing an ID does not
+// allocate. Call Flush when done. An IDWriter is not safe for concurrent use.
+type IDWriter struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewIDWriter returns an IDWriter writing to w.
+func NewIDWriter(w io.Writer) *IDWriter {
+	return &IDWriter{w: bufio.NewWriter(w)}
+}
+
+// WriteID writes id in String form followed by a newline.
+func (w *IDWriter) WriteID(id EmojiID) error {
+	w.buf = append(id.AppendString(w.buf[:0]), '\n')
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *IDWriter) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,80 @@
+package emojid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestIDWriter(t *testing.T) {
+	ids := make([]EmojiID, 100)
+	for i := range ids {
+		ids[i] = MustNew()
+	}
+
+	var b strings.Builder
+	w := NewIDWriter(&b)
+	for _, id := range ids {
+		if err := w.WriteID(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != len(ids) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(ids))
+	}
+	for i, line := range lines {
+		if line != ids[i].String() {
+			t.Errorf("line %d = %q, want %q", i, line, ids[i].String())
+		}
+	}
+}
+
+func TestIDWriterAllocs(t *testing.T) {
+	id := MustNew()
+	w := NewIDWriter(io.Discard)
+	w.WriteID(id) // grow the buffer
+	if n := testing.AllocsPerRun(100, func() { w.WriteID(id) }); n != 0 {
+		t.Errorf("WriteID allocates %v times, want 0", n)
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	id := MustNew()
+	prefix := []byte("id=")
+	if got := string(id.AppendString(prefix)); got != "id="+id.String() {
+		t.Errorf("AppendString = %q", got)
+	}
+	buf := make([]byte, 0, 256)
+	if n := testing.AllocsPerRun(100, func() { buf = id.AppendString(buf[:0]) }); n != 0 {
+		t.Errorf("AppendString allocates %v times, want 0", n)
+	}
+}
+
+func BenchmarkIDWriter(b *testing.B) {
+	id := MustNew()
+	w := NewIDWriter(io.Discard)
+	for b.Loop() {
+		if err := w.WriteID(id); err != nil {
+			b.Fatal(err)
+		}
+	}
+	w.Flush()
+}
+
+func BenchmarkFprintln(b *testing.B) {
+	id := MustNew()
+	w := bufio.NewWriter(io.Discard)
+	for b.Loop() {
+		if _, err := fmt.Fprintln(w, id); err != nil {
+			b.Fatal(err)
+		}
+	}
+	w.Flush()
+}